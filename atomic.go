@@ -0,0 +1,121 @@
+// Copyright (c) 2025 srfrog - https://srfrog.dev
+// Use of this source code is governed by the license in the LICENSE file.
+
+package dict
+
+import "reflect"
+
+// The funcs in this file mirror the methods of sync.Map. Each one runs under a single
+// lock of the dict, so they are atomic with respect to every other dict method. Values
+// are compared with reflect.DeepEqual, as in Set.
+
+// CompareAndSwap replaces the value of key with new if the current value is equal to
+// old.
+// Returns true if the value was swapped, false otherwise.
+func (d *Dict) CompareAndSwap(key, old, new interface{}) bool {
+	if d == nil {
+		return false
+	}
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	id, ok := d.keyID(key)
+	if !ok {
+		return false
+	}
+	curr, ok := d.values[id]
+	if !ok || !reflect.DeepEqual(curr, old) {
+		return false
+	}
+	d.replaceValue(id, new)
+
+	return true
+}
+
+// CompareAndDelete removes the item of key if its value is equal to old.
+// Returns true if the item was removed, false otherwise.
+func (d *Dict) CompareAndDelete(key, old interface{}) bool {
+	if d.IsEmpty() {
+		return false
+	}
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	id, ok := d.keyID(key)
+	if !ok {
+		return false
+	}
+	curr, ok := d.values[id]
+	if !ok || !reflect.DeepEqual(curr, old) {
+		return false
+	}
+	_, ok = d.deleteKey(id)
+
+	return ok
+}
+
+// LoadOrStore gets the value of key if found, otherwise it adds an item with value.
+// Returns the existing value and true if key was found, otherwise value and false.
+func (d *Dict) LoadOrStore(key, value interface{}) (interface{}, bool) {
+	if d == nil {
+		return value, false
+	}
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	k, ok := d.makeKey(key)
+	if !ok {
+		return value, false
+	}
+	if curr, ok := d.values[k.ID]; ok {
+		return curr, true
+	}
+	d.insertKey(k, value)
+
+	return value, false
+}
+
+// LoadAndDelete removes the item of key.
+// Returns the value of the item and true if found, otherwise nil and false.
+func (d *Dict) LoadAndDelete(key interface{}) (interface{}, bool) {
+	if d.IsEmpty() {
+		return nil, false
+	}
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	return d.popKey(key)
+}
+
+// Compute calls fn with the current value of key, and whether it was found, and sets the
+// value to the one returned by fn. If fn returns keep as false, the item is removed
+// instead. fn is called with the dict locked, so it must not call methods of d.
+// Returns the new value and true if it was kept, otherwise nil and false.
+func (d *Dict) Compute(key interface{}, fn func(old interface{}, ok bool) (new interface{}, keep bool)) (interface{}, bool) {
+	if d == nil {
+		return nil, false
+	}
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	k, ok := d.makeKey(key)
+	if !ok {
+		return nil, false
+	}
+	old, ok := d.values[k.ID]
+	value, keep := fn(old, ok)
+	if !keep {
+		if ok {
+			d.deleteKey(k.ID)
+		}
+		return nil, false
+	}
+	d.setKey(k, value)
+
+	return value, true
+}
@@ -0,0 +1,97 @@
+// Copyright (c) 2025 srfrog - https://srfrog.dev
+// Use of this source code is governed by the license in the LICENSE file.
+
+package dict
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCompareAndSwap(t *testing.T) {
+	d := New().Set("a", 1).Set("b", []int{1, 2})
+
+	require.False(t, d.CompareAndSwap("a", 2, 3))
+	require.False(t, d.CompareAndSwap("missing", nil, 3))
+	require.False(t, d.Key("missing"))
+	require.True(t, d.CompareAndSwap("a", 1, 3))
+	require.Equal(t, 3, d.Get("a"))
+	require.True(t, d.CompareAndSwap("b", []int{1, 2}, "x"))
+	require.Equal(t, "x", d.Get("b"))
+
+	ver := d.Version()
+	require.False(t, d.CompareAndDelete("a", 1))
+	require.True(t, d.CompareAndDelete("a", 3))
+	require.False(t, d.Key("a"))
+	require.Equal(t, ver+1, d.Version())
+
+	var nd *Dict
+	require.False(t, nd.CompareAndSwap("a", nil, 1))
+	require.False(t, nd.CompareAndDelete("a", nil))
+}
+
+func TestLoadOrStore(t *testing.T) {
+	d := New()
+
+	v, loaded := d.LoadOrStore("a", 1)
+	require.False(t, loaded)
+	require.Equal(t, 1, v)
+
+	v, loaded = d.LoadOrStore("a", 2)
+	require.True(t, loaded)
+	require.Equal(t, 1, v)
+
+	v, loaded = d.LoadAndDelete("a")
+	require.True(t, loaded)
+	require.Equal(t, 1, v)
+	require.True(t, d.IsEmpty())
+
+	v, loaded = d.LoadAndDelete("a")
+	require.False(t, loaded)
+	require.Nil(t, v)
+}
+
+func TestCompute(t *testing.T) {
+	d := New()
+	incr := func(old interface{}, ok bool) (interface{}, bool) {
+		if !ok {
+			return 1, true
+		}
+		return old.(int) + 1, true
+	}
+
+	v, ok := d.Compute("n", incr)
+	require.True(t, ok)
+	require.Equal(t, 1, v)
+	v, _ = d.Compute("n", incr)
+	require.Equal(t, 2, v)
+
+	v, ok = d.Compute("n", func(old interface{}, ok bool) (interface{}, bool) {
+		return nil, false
+	})
+	require.False(t, ok)
+	require.Nil(t, v)
+	require.False(t, d.Key("n"))
+
+	ver := d.Version()
+	d.Compute("n", func(old interface{}, ok bool) (interface{}, bool) {
+		return nil, false
+	})
+	require.Equal(t, ver, d.Version())
+
+	// Concurrent increments are not lost.
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 100; j++ {
+				d.Compute("n", incr)
+			}
+		}()
+	}
+	wg.Wait()
+	require.Equal(t, 800, d.Get("n"))
+}
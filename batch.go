@@ -0,0 +1,101 @@
+// Copyright (c) 2025 srfrog - https://srfrog.dev
+// Use of this source code is governed by the license in the LICENSE file.
+
+package dict
+
+import "sync/atomic"
+
+// SetMany inserts or replaces the items in d, in order, taking the lock once. It's faster
+// than Update for bulk loading, since items are not sent through a channel.
+// Returns a slice with the result of each item: true if its key was added to d, false if
+// an existing value was replaced or the key is not valid.
+func (d *Dict) SetMany(items []Item) []bool {
+	res := make([]bool, len(items))
+	if d == nil || len(items) == 0 {
+		return res
+	}
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	// grow is geometric, so repeated small batches don't copy the dict every time.
+	d.grow(len(items))
+
+	for i := range items {
+		k, ok := d.makeKey(items[i].Key)
+		if !ok {
+			continue
+		}
+		_, found := d.values[k.ID]
+		d.setKey(k, items[i].Value)
+		res[i] = !found
+	}
+
+	return res
+}
+
+// GetMany retrieves the values of keys, taking the lock once.
+// Returns a slice with the value of each key, or nil if not found, and a slice with true
+// for each key found, false otherwise.
+func (d *Dict) GetMany(keys ...interface{}) ([]interface{}, []bool) {
+	values, found := make([]interface{}, len(keys)), make([]bool, len(keys))
+	if d.IsEmpty() {
+		return values, found
+	}
+
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+
+	for i := range keys {
+		if id, ok := d.keyID(keys[i]); ok {
+			values[i], found[i] = d.values[id]
+		}
+	}
+
+	return values, found
+}
+
+// DelMany removes the items of keys, taking the lock once. The key order of d is
+// compacted in one pass, instead of once per item as with Del.
+// Returns a slice with true for each key found and removed, false otherwise.
+func (d *Dict) DelMany(keys ...interface{}) []bool {
+	res := make([]bool, len(keys))
+	if d.IsEmpty() {
+		return res
+	}
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	var n int
+	for i := range keys {
+		id, ok := d.keyID(keys[i])
+		if !ok {
+			continue
+		}
+		if _, ok := d.values[id]; ok {
+			delete(d.values, id)
+			res[i] = true
+			n++
+		}
+	}
+	if n == 0 {
+		return res
+	}
+
+	keep := d.keys[:0]
+	for _, k := range d.keys {
+		if _, ok := d.values[k.ID]; ok {
+			keep = append(keep, k)
+		}
+	}
+	for i := len(keep); i < len(d.keys); i++ {
+		d.keys[i] = Key{}
+	}
+	d.keys = keep
+
+	atomic.StoreInt64(&d.size, int64(len(d.keys)))
+	atomic.AddInt64(&d.version, int64(n))
+
+	return res
+}
@@ -0,0 +1,57 @@
+// Copyright (c) 2025 srfrog - https://srfrog.dev
+// Use of this source code is governed by the license in the LICENSE file.
+
+package dict
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSetMany(t *testing.T) {
+	d := New().Set("a", 1)
+	ver := d.Version()
+
+	res := d.SetMany([]Item{{"a", 10}, {"b", 2}, {nil, 3}, {"b", 20}, {"c", 3}})
+	require.Equal(t, []bool{false, true, false, false, true}, res)
+	require.Equal(t, []string{"a", "b", "c"}, d.Keys())
+	require.Equal(t, []interface{}{10, 20, 3}, d.Values())
+	require.Equal(t, 3, d.Len())
+	require.Equal(t, ver+4, d.Version())
+
+	require.Equal(t, []bool{true}, (&Dict{}).SetMany([]Item{{"a", 1}}))
+	require.Empty(t, d.SetMany(nil))
+
+	var nd *Dict
+	require.Equal(t, []bool{false}, nd.SetMany([]Item{{"a", 1}}))
+}
+
+func TestGetMany(t *testing.T) {
+	d := New().Set("a", 1).Set(2, nil)
+
+	values, found := d.GetMany("a", "missing", 2, nil)
+	require.Equal(t, []interface{}{1, nil, nil, nil}, values)
+	require.Equal(t, []bool{true, false, true, false}, found)
+
+	values, found = New().GetMany("a")
+	require.Equal(t, []interface{}{nil}, values)
+	require.Equal(t, []bool{false}, found)
+}
+
+func TestDelMany(t *testing.T) {
+	d := New(map[string]int{"a": 1, "b": 2, "c": 3, "d": 4})
+	d.SortKeys(nil)
+	ver := d.Version()
+
+	require.Equal(t, []bool{true, false, true, false}, d.DelMany("b", "x", "d", "b"))
+	require.Equal(t, []string{"a", "c"}, d.Keys())
+	require.Equal(t, 2, d.Len())
+	require.Equal(t, ver+2, d.Version())
+
+	require.Equal(t, []bool{false}, d.DelMany("x"))
+	require.Equal(t, ver+2, d.Version())
+	require.Equal(t, []bool{true, true}, d.DelMany("a", "c"))
+	require.True(t, d.IsEmpty())
+	require.Equal(t, []bool{false}, d.DelMany("a"))
+}
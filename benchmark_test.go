@@ -3,7 +3,11 @@
 
 package dict
 
-import "testing"
+import (
+	"strconv"
+	"sync/atomic"
+	"testing"
+)
 
 const N = 1 << 10
 
@@ -54,3 +58,171 @@ func BenchmarkDictGet(b *testing.B) {
 		}
 	})
 }
+
+func newConcurrentDict(b *testing.B) *ConcurrentDict {
+	d := NewConcurrent(0)
+	for i := 0; i < N; i++ {
+		d.Set(i, i)
+	}
+
+	b.ResetTimer()
+	return d
+}
+
+func BenchmarkConcurrentDictGet(b *testing.B) {
+	d := newConcurrentDict(b)
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			for i := 0; i < N; i++ {
+				v := d.Get(i)
+				if v != i {
+					b.Fail()
+				}
+			}
+		}
+	})
+}
+
+func BenchmarkDictSet(b *testing.B) {
+	d := newDict(b)
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			for i := 0; i < N; i++ {
+				d.Set(i, i+1)
+			}
+		}
+	})
+}
+
+func BenchmarkConcurrentDictSet(b *testing.B) {
+	d := newConcurrentDict(b)
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			for i := 0; i < N; i++ {
+				d.Set(i, i+1)
+			}
+		}
+	})
+}
+
+func BenchmarkDictInsert(b *testing.B) {
+	d := New()
+	var seq int64
+	b.ResetTimer()
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			d.Set(int(atomic.AddInt64(&seq, 1)), 1)
+		}
+	})
+}
+
+func BenchmarkConcurrentDictInsert(b *testing.B) {
+	d := NewConcurrent(0)
+	var seq int64
+	b.ResetTimer()
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			d.Set(int(atomic.AddInt64(&seq, 1)), 1)
+		}
+	})
+}
+
+func newItems() []Item {
+	items := make([]Item, N)
+	for i := range items {
+		items[i] = Item{Key: i, Value: i}
+	}
+	return items
+}
+
+func BenchmarkDictUpdate(b *testing.B) {
+	items := newItems()
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		New().Update(items)
+	}
+}
+
+func BenchmarkDictSetMany(b *testing.B) {
+	items := newItems()
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		New().SetMany(items)
+	}
+}
+
+func BenchmarkDictSetManyRepeated(b *testing.B) {
+	for _, size := range []int{1, 8, 64} {
+		b.Run(strconv.Itoa(size), func(b *testing.B) {
+			items := make([]Item, size)
+			d := New()
+			b.ReportAllocs()
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				for j := range items {
+					items[j] = Item{Key: i*size + j, Value: j}
+				}
+				d.SetMany(items)
+			}
+		})
+	}
+}
+
+func BenchmarkDictGrow(b *testing.B) {
+	d := New()
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		d.Grow(1)
+		d.Set(i, i)
+	}
+}
+
+func BenchmarkDictGetMany(b *testing.B) {
+	d := newDict(b)
+	keys := make([]interface{}, N)
+	for i := range keys {
+		keys[i] = i
+	}
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		d.GetMany(keys...)
+	}
+}
+
+func BenchmarkDictSetAllocs(b *testing.B) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		d := NewWithCapacity(N)
+		for j := 0; j < N; j++ {
+			d.Set(j, j)
+		}
+	}
+}
+
+func BenchmarkDictGetAllocs(b *testing.B) {
+	d := newDict(b)
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		for j := 0; j < N; j++ {
+			_ = d.Get(j)
+		}
+	}
+}
+
+func BenchmarkDictDelAllocs(b *testing.B) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		b.StopTimer()
+		d := NewWithCapacity(N)
+		for j := 0; j < N; j++ {
+			d.Set(j, j)
+		}
+		b.StartTimer()
+		for j := N - 1; j >= 0; j-- {
+			d.Del(j)
+		}
+	}
+}
@@ -0,0 +1,232 @@
+// Copyright (c) 2025 srfrog - https://srfrog.dev
+// Use of this source code is governed by the license in the LICENSE file.
+
+package dict
+
+import (
+	"errors"
+	"sync"
+)
+
+// Errors returned by BiDict.
+var (
+	ErrInvalidKey     = errors.New("dict: invalid key type")
+	ErrDuplicateValue = errors.New("dict: duplicate value")
+)
+
+// DuplicatePolicy defines what a BiDict does when a value is set that already belongs to
+// another key.
+type DuplicatePolicy int
+
+// Duplicate value policies.
+const (
+	// RejectDuplicates fails with ErrDuplicateValue.
+	RejectDuplicates DuplicatePolicy = iota
+	// OverwriteDuplicates removes the other key and sets the value.
+	OverwriteDuplicates
+)
+
+// BiDict is a bidirectional dict, with a one-to-one mapping between keys and values. The
+// values must be hashable key types, as defined by MakeKey, and are indexed so that
+// finding a key by value is as fast as finding a value by key.
+// Use NewBiDict to create a BiDict.
+type BiDict struct {
+	d       *Dict
+	inverse map[uint64]*Key
+	policy  DuplicatePolicy
+	mu      sync.RWMutex
+}
+
+// NewBiDict returns a new BiDict object that handles duplicate values with policy.
+func NewBiDict(policy DuplicatePolicy) *BiDict {
+	return &BiDict{
+		d:       New(),
+		inverse: make(map[uint64]*Key),
+		policy:  policy,
+	}
+}
+
+// Version returns the version of the dictionary. The version is increased after every
+// change to dict items.
+func (b *BiDict) Version() int {
+	return b.d.Version()
+}
+
+// Len returns the number of items in the dict.
+func (b *BiDict) Len() int {
+	return b.d.Len()
+}
+
+// Set inserts a new item into the dict, or replaces the value of an existing key. If the
+// value belongs to another key, the policy of the dict is applied.
+// Returns ErrInvalidKey if the key or value is not a hashable key type, or
+// ErrDuplicateValue if the value is rejected.
+func (b *BiDict) Set(key, value interface{}) error {
+	k, v := MakeKey(key), MakeKey(value)
+	if k == nil || v == nil {
+		return ErrInvalidKey
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if owner, ok := b.inverse[v.ID]; ok && owner.ID != k.ID {
+		if b.policy == RejectDuplicates {
+			return ErrDuplicateValue
+		}
+		b.d.Del(owner.Name)
+	}
+	if old := MakeKey(b.d.Get(k.Name)); old != nil {
+		delete(b.inverse, old.ID)
+	}
+
+	b.d.Set(key, value)
+	b.inverse[v.ID] = k
+
+	return nil
+}
+
+// Get retrieves the value of key. If alt value is passed, it will be used as default
+// value if no item is found.
+// Returns a value matching key, otherwise nil or alt if given.
+func (b *BiDict) Get(key interface{}, alt ...interface{}) interface{} {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	return b.d.Get(key, alt...)
+}
+
+// GetKey retrieves the key name of value. If alt value is passed, it will be used as
+// default value if no item is found.
+// Returns a key name matching value, otherwise nil or alt if given.
+func (b *BiDict) GetKey(value interface{}, alt ...interface{}) interface{} {
+	if v := MakeKey(value); v != nil {
+		b.mu.RLock()
+		defer b.mu.RUnlock()
+
+		if k, ok := b.inverse[v.ID]; ok {
+			return k.Name
+		}
+	}
+	if alt != nil {
+		return alt[0]
+	}
+	return nil
+}
+
+// Key returns true if key is in the dict, false otherwise.
+func (b *BiDict) Key(key interface{}) bool {
+	return b.d.Key(key)
+}
+
+// Value returns true if value is in the dict, false otherwise.
+func (b *BiDict) Value(value interface{}) bool {
+	v := MakeKey(value)
+	if v == nil {
+		return false
+	}
+
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	_, ok := b.inverse[v.ID]
+	return ok
+}
+
+// Del removes an item from the dict by key.
+// Returns true if an item is found and removed, false otherwise.
+func (b *BiDict) Del(key interface{}) bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if !b.d.Key(key) {
+		return false
+	}
+	if v := MakeKey(b.d.Get(key)); v != nil {
+		delete(b.inverse, v.ID)
+	}
+	return b.d.Del(key)
+}
+
+// DelValue removes an item from the dict by value.
+// Returns true if an item is found and removed, false otherwise.
+func (b *BiDict) DelValue(value interface{}) bool {
+	v := MakeKey(value)
+	if v == nil {
+		return false
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	k, ok := b.inverse[v.ID]
+	if !ok {
+		return false
+	}
+	delete(b.inverse, v.ID)
+	return b.d.Del(k.Name)
+}
+
+// Keys returns a string slice of all dict keys, or nil if dict is empty.
+func (b *BiDict) Keys() []string {
+	return b.d.Keys()
+}
+
+// Values returns a slice of all dict values, or nil if dict is empty.
+func (b *BiDict) Values() []interface{} {
+	return b.d.Values()
+}
+
+// Items returns a channel of key-value items.
+func (b *BiDict) Items() <-chan Item {
+	return b.d.Items()
+}
+
+// Inverse returns a live read-only view of the dict with values as keys.
+func (b *BiDict) Inverse() InverseView {
+	return InverseView{b: b}
+}
+
+// String implements the fmt.Stringer interface to print b similar to a Python dict.
+func (b *BiDict) String() string {
+	return b.d.String()
+}
+
+// MarshalJSON implements the json.MarshalJSON interface.
+// The JSON representation of a bidict is just a JSON object.
+func (b *BiDict) MarshalJSON() ([]byte, error) {
+	return b.d.MarshalJSON()
+}
+
+// InverseView is a read-only view of a BiDict, mapping values to key names. The view
+// reads through to the dict, so it always reflects its current state.
+type InverseView struct {
+	b *BiDict
+}
+
+// Len returns the number of items in the dict.
+func (v InverseView) Len() int {
+	return v.b.Len()
+}
+
+// Get retrieves the key name of value, as in BiDict.GetKey().
+func (v InverseView) Get(value interface{}, alt ...interface{}) interface{} {
+	return v.b.GetKey(value, alt...)
+}
+
+// Key returns true if value is in the dict, false otherwise.
+func (v InverseView) Key(value interface{}) bool {
+	return v.b.Value(value)
+}
+
+// Items returns a channel of value-key items, in the order of the dict.
+func (v InverseView) Items() <-chan Item {
+	ci := make(chan Item)
+	go func() {
+		defer close(ci)
+		for item := range v.b.Items() {
+			ci <- Item{Key: toString(item.Value), Value: item.Key}
+		}
+	}()
+	return ci
+}
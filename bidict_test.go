@@ -0,0 +1,89 @@
+// Copyright (c) 2025 srfrog - https://srfrog.dev
+// Use of this source code is governed by the license in the LICENSE file.
+
+package dict
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestBiDict(t *testing.T) {
+	b := NewBiDict(RejectDuplicates)
+	require.NoError(t, b.Set("2C3KA43R08H129584", 1001))
+	require.NoError(t, b.Set("1N6AD07U78C416152", 1002))
+	require.NoError(t, b.Set("WDDGF8AB8EA940372", testDevice(0x3)))
+
+	require.Equal(t, 3, b.Len())
+	require.Equal(t, 1002, b.Get("1N6AD07U78C416152"))
+	require.Equal(t, "1N6AD07U78C416152", b.GetKey(1002))
+	require.Equal(t, "1N6AD07U78C416152", b.GetKey("1002"))
+	require.Equal(t, "WDDGF8AB8EA940372", b.GetKey("0x3"))
+	require.Nil(t, b.GetKey(9999))
+	require.Equal(t, "alt", b.GetKey(9999, "alt"))
+	require.True(t, b.Key("2C3KA43R08H129584"))
+	require.True(t, b.Value(1001))
+	require.False(t, b.Value(nil))
+
+	// Invalid and duplicate values.
+	require.Equal(t, ErrInvalidKey, b.Set("x", []int{1}))
+	require.Equal(t, ErrInvalidKey, b.Set(nil, 1))
+	require.Equal(t, ErrDuplicateValue, b.Set("x", 1001))
+	require.Equal(t, 3, b.Len())
+
+	// Replacing a value updates the index.
+	require.NoError(t, b.Set("2C3KA43R08H129584", 1004))
+	require.False(t, b.Value(1001))
+	require.Equal(t, "2C3KA43R08H129584", b.GetKey(1004))
+	require.NoError(t, b.Set("x", 1001))
+
+	require.True(t, b.Del("x"))
+	require.False(t, b.Del("x"))
+	require.False(t, b.Value(1001))
+	require.True(t, b.DelValue(1004))
+	require.False(t, b.DelValue(1004))
+	require.False(t, b.Key("2C3KA43R08H129584"))
+	require.Equal(t, []string{"1N6AD07U78C416152", "WDDGF8AB8EA940372"}, b.Keys())
+	require.Equal(t, []interface{}{1002, testDevice(0x3)}, b.Values())
+
+	out, err := json.Marshal(b)
+	require.NoError(t, err)
+	require.JSONEq(t, `{"1N6AD07U78C416152": 1002, "WDDGF8AB8EA940372": 3}`, string(out))
+}
+
+func TestBiDictOverwrite(t *testing.T) {
+	b := NewBiDict(OverwriteDuplicates)
+	require.NoError(t, b.Set("a", 1))
+	require.NoError(t, b.Set("b", 2))
+	require.NoError(t, b.Set("c", 1))
+
+	require.Equal(t, []string{"b", "c"}, b.Keys())
+	require.Equal(t, "c", b.GetKey(1))
+	require.False(t, b.Key("a"))
+	require.Equal(t, `{b: 2, c: 1}`, b.String())
+}
+
+func TestBiDictInverse(t *testing.T) {
+	b := NewBiDict(RejectDuplicates)
+	inv := b.Inverse()
+	require.NoError(t, b.Set("one", 1))
+	require.NoError(t, b.Set("two", 2))
+
+	require.Equal(t, 2, inv.Len())
+	require.Equal(t, "two", inv.Get(2))
+	require.True(t, inv.Key(1))
+	require.False(t, inv.Key(3))
+
+	require.NoError(t, b.Set("three", 3))
+	var items []Item
+	for item := range inv.Items() {
+		items = append(items, item)
+	}
+	require.Equal(t, []Item{
+		{Key: "1", Value: "one"},
+		{Key: "2", Value: "two"},
+		{Key: "3", Value: "three"},
+	}, items)
+}
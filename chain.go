@@ -0,0 +1,123 @@
+// Copyright (c) 2025 srfrog - https://srfrog.dev
+// Use of this source code is governed by the license in the LICENSE file.
+
+package dict
+
+// ChainMap groups several dicts, or layers, to create a single view like Python
+// collections.ChainMap. Lookups search the layers in order until a key is found, while
+// writes and deletions only change the first layer. The layers are not copied, so changes
+// to them are reflected in the chain.
+//
+// When iterating, each key appears once with the value of the first layer that has it.
+// The keys are ordered as Python does, as if the layers were merged from the last to the
+// first: the keys of the last layer come first in its order, followed by the new keys of
+// each previous layer.
+type ChainMap struct {
+	maps []*Dict
+}
+
+// NewChainMap returns a new ChainMap object with maps as layers, from highest to lowest
+// precedence. Nil layers are ignored. If no layers are given, a single empty dict is used.
+func NewChainMap(maps ...*Dict) *ChainMap {
+	c := &ChainMap{}
+	for _, m := range maps {
+		if m != nil {
+			c.maps = append(c.maps, m)
+		}
+	}
+	if c.maps == nil {
+		c.maps = []*Dict{New()}
+	}
+	return c
+}
+
+// Layers returns a copy of the list of dicts in the chain, from first to last.
+func (c *ChainMap) Layers() []*Dict {
+	return append([]*Dict(nil), c.maps...)
+}
+
+// NewChild returns a new chain with child as the first layer, followed by all the layers
+// of c. If child is nil, a new empty dict is used.
+func (c *ChainMap) NewChild(child *Dict) *ChainMap {
+	if child == nil {
+		child = New()
+	}
+	return NewChainMap(append([]*Dict{child}, c.maps...)...)
+}
+
+// Parents returns a new chain with all the layers of c except the first.
+func (c *ChainMap) Parents() *ChainMap {
+	return NewChainMap(c.maps[1:]...)
+}
+
+// Get retrieves the value of key from the first layer that has it. If alt value is
+// passed, it will be used as default value if no item is found.
+// Returns a value matching key, otherwise nil or alt if given.
+func (c *ChainMap) Get(key interface{}, alt ...interface{}) interface{} {
+	for _, m := range c.maps {
+		if value, ok := m.lookup(key); ok {
+			return value
+		}
+	}
+	if alt != nil {
+		return alt[0]
+	}
+	return nil
+}
+
+// Key returns true if key is in any layer, false otherwise.
+func (c *ChainMap) Key(key interface{}) bool {
+	for _, m := range c.maps {
+		if m.Key(key) {
+			return true
+		}
+	}
+	return false
+}
+
+// Set inserts or replaces an item in the first layer.
+func (c *ChainMap) Set(key, value interface{}) *ChainMap {
+	c.maps[0].Set(key, value)
+	return c
+}
+
+// Del removes an item from the first layer. Items in other layers are not removed.
+// Returns true if an item is found and removed, false otherwise.
+func (c *ChainMap) Del(key interface{}) bool {
+	return c.maps[0].Del(key)
+}
+
+// Dict returns a new dict with the merged view of the chain.
+func (c *ChainMap) Dict() *Dict {
+	d := New()
+	for i := len(c.maps) - 1; i >= 0; i-- {
+		d.Update(c.maps[i])
+	}
+	return d
+}
+
+// Len returns the number of unique keys in the chain.
+func (c *ChainMap) Len() int {
+	return c.Dict().Len()
+}
+
+// Keys returns a string slice of the unique keys in the chain, or nil if it's empty.
+func (c *ChainMap) Keys() []string {
+	return c.Dict().Keys()
+}
+
+// Items returns a channel of key-value items of the merged view of the chain.
+func (c *ChainMap) Items() <-chan Item {
+	return c.Dict().Items()
+}
+
+// String implements the fmt.Stringer interface to print c similar to a Python dict.
+func (c *ChainMap) String() string {
+	return c.Dict().String()
+}
+
+// MarshalJSON implements the json.MarshalJSON interface.
+// The JSON representation of a chain is a JSON object of its merged view.
+func (c *ChainMap) MarshalJSON() ([]byte, error) {
+	return c.Dict().MarshalJSON()
+}
@@ -0,0 +1,82 @@
+// Copyright (c) 2025 srfrog - https://srfrog.dev
+// Use of this source code is governed by the license in the LICENSE file.
+
+package dict
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestChainMap(t *testing.T) {
+	defaults := New().Set("color", "red").Set("user", "guest").Set("lang", "en")
+	prefs := New().Set("lang", "fr").Set("theme", "dark")
+	request := New().Set("color", "blue")
+
+	c := NewChainMap(request, nil, prefs, defaults)
+	require.Len(t, c.Layers(), 3)
+
+	require.Equal(t, "blue", c.Get("color"))
+	require.Equal(t, "fr", c.Get("lang"))
+	require.Equal(t, "guest", c.Get("user"))
+	require.Nil(t, c.Get("missing"))
+	require.Equal(t, "alt", c.Get("missing", "alt"))
+	require.True(t, c.Key("theme"))
+	require.False(t, c.Key("missing"))
+
+	require.Equal(t, 4, c.Len())
+	require.Equal(t, []string{"color", "user", "lang", "theme"}, c.Keys())
+	require.Equal(t, "{color: \"blue\", user: \"guest\", lang: \"fr\", theme: \"dark\"}", c.String())
+
+	b, err := json.Marshal(c)
+	require.NoError(t, err)
+	require.JSONEq(t, `{"color": "blue", "user": "guest", "lang": "fr", "theme": "dark"}`, string(b))
+
+	var n int
+	for range c.Items() {
+		n++
+	}
+	require.Equal(t, 4, n)
+
+	// Writes go to the first layer.
+	c.Set("user", "admin")
+	require.Equal(t, "admin", request.Get("user"))
+	require.Equal(t, "guest", defaults.Get("user"))
+	require.False(t, c.Del("lang"))
+	require.True(t, c.Del("user"))
+	require.Equal(t, "guest", c.Get("user"))
+
+	// Changes to layers are visible.
+	defaults.Set("font", "mono")
+	require.Equal(t, "mono", c.Get("font"))
+}
+
+func TestChainMapLayers(t *testing.T) {
+	base := New().Set("a", 1)
+	c := NewChainMap(base)
+
+	child := c.NewChild(nil)
+	child.Set("a", 2)
+	require.Equal(t, 2, child.Get("a"))
+	require.Equal(t, 1, c.Get("a"))
+	require.Len(t, child.Layers(), 2)
+
+	child = child.NewChild(New().Set("b", 3))
+	require.Equal(t, []string{"a", "b"}, child.Keys())
+	require.Equal(t, 2, child.Get("a"))
+
+	parents := child.Parents()
+	require.False(t, parents.Key("b"))
+	require.Equal(t, 2, parents.Get("a"))
+	require.Equal(t, 1, parents.Parents().Get("a"))
+
+	// Empty chains have a single empty layer.
+	empty := c.Parents()
+	require.Len(t, empty.Layers(), 1)
+	require.Zero(t, empty.Len())
+	empty.Set("x", 1)
+	require.Equal(t, 1, empty.Get("x"))
+	require.Len(t, NewChainMap().Layers(), 1)
+}
@@ -0,0 +1,324 @@
+// Copyright (c) 2025 srfrog - https://srfrog.dev
+// Use of this source code is governed by the license in the LICENSE file.
+
+package dict
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+	"sync"
+	"sync/atomic"
+)
+
+// DefaultShards is the number of segments of a ConcurrentDict when none is given.
+const DefaultShards = 32
+
+// ConcurrentDict is a dict for high write contention. The items are split by Key.ID into
+// independently locked segments, so writes to different segments don't block each other.
+// Every new item gets a sequence number, which keeps the global insertion order for
+// Keys, Values, Items and JSON encoding.
+//
+// Operations on a single key are atomic. Operations on all items, like Items and Keys,
+// lock one segment at a time, so they don't see a consistent snapshot of the dict while
+// it's being changed.
+// Use NewConcurrent to create a ConcurrentDict.
+type ConcurrentDict struct {
+	// The counters are changed by every write, so each one has its own cache line to avoid
+	// false sharing between writers. They're kept first for 64-bit alignment.
+	size    int64
+	_       [cacheLineSize - 8]byte
+	version int64
+	_       [cacheLineSize - 8]byte
+	seq     int64
+	_       [cacheLineSize - 8]byte
+
+	shards []*shard
+	mask   uint64
+}
+
+// cacheLineSize is the size of a CPU cache line on common platforms.
+const cacheLineSize = 64
+
+type shard struct {
+	items map[uint64]*shardItem
+	mu    sync.RWMutex
+}
+
+type shardItem struct {
+	key   *Key
+	value interface{}
+	seq   int64
+}
+
+// NewConcurrent returns a new ConcurrentDict object with n segments. The number of
+// segments is rounded up to a power of two, and DefaultShards is used if n <= 0.
+// vargs are the initial values, as in New().
+func NewConcurrent(n int, vargs ...interface{}) *ConcurrentDict {
+	if n <= 0 {
+		n = DefaultShards
+	}
+	size := 1
+	for size < n {
+		size <<= 1
+	}
+
+	d := &ConcurrentDict{
+		shards: make([]*shard, size),
+		mask:   uint64(size - 1),
+	}
+	for i := range d.shards {
+		d.shards[i] = &shard{items: make(map[uint64]*shardItem)}
+	}
+	d.Update(vargs...)
+
+	return d
+}
+
+func (d *ConcurrentDict) shard(id uint64) *shard {
+	return d.shards[id&d.mask]
+}
+
+// Version returns the version of the dictionary. The version is increased after every
+// change to dict items.
+// Returns version, which is zero (0) initially.
+func (d *ConcurrentDict) Version() int {
+	return int(atomic.LoadInt64(&d.version))
+}
+
+// Len returns the size of a ConcurrentDict.
+func (d *ConcurrentDict) Len() int {
+	return int(atomic.LoadInt64(&d.size))
+}
+
+// IsEmpty returns true if the dict is empty, false otherwise.
+func (d *ConcurrentDict) IsEmpty() bool {
+	return d == nil || d.Len() == 0
+}
+
+// Set inserts a new item into the dict. If a value matching the key already exists,
+// its value is replaced, otherwise a new item is added.
+func (d *ConcurrentDict) Set(key, value interface{}) *ConcurrentDict {
+	// Sanity: don't panic on nil dict, just create a new one.
+	if d == nil {
+		d = NewConcurrent(0)
+	}
+
+	k := MakeKey(key)
+	if k == nil {
+		return d
+	}
+
+	s := d.shard(k.ID)
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if item, ok := s.items[k.ID]; ok {
+		curr := item.value
+		item.value = value
+
+		// Value changed, update version.
+		if !reflect.DeepEqual(value, curr) {
+			atomic.AddInt64(&d.version, 1)
+		}
+
+		return d
+	}
+
+	s.items[k.ID] = &shardItem{
+		key:   k,
+		value: value,
+		seq:   atomic.AddInt64(&d.seq, 1),
+	}
+	atomic.AddInt64(&d.size, 1)
+	atomic.AddInt64(&d.version, 1)
+
+	return d
+}
+
+// Get retrieves an item from dict by key. If alt value is passed, it will be used as
+// default value if no item is found.
+// Returns a value matching key in dict, otherwise nil or alt if given.
+func (d *ConcurrentDict) Get(key interface{}, alt ...interface{}) interface{} {
+	if id, ok := hashKey(key, nil); ok && !d.IsEmpty() {
+		s := d.shard(id)
+		s.mu.RLock()
+		defer s.mu.RUnlock()
+
+		if item, ok := s.items[id]; ok {
+			return item.value
+		}
+	}
+	if alt != nil {
+		return alt[0]
+	}
+	return nil
+}
+
+// Key returns true if key is in dict d, false otherwise.
+func (d *ConcurrentDict) Key(key interface{}) bool {
+	id, ok := hashKey(key, nil)
+	if !ok || d.IsEmpty() {
+		return false
+	}
+
+	s := d.shard(id)
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	_, ok = s.items[id]
+	return ok
+}
+
+// Del removes an item from dict by key name.
+// Returns true if an item is found and removed, false otherwise.
+func (d *ConcurrentDict) Del(key interface{}) bool {
+	id, ok := hashKey(key, nil)
+	if !ok || d.IsEmpty() {
+		return false
+	}
+
+	s := d.shard(id)
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.items[id]; !ok {
+		return false
+	}
+	delete(s.items, id)
+	atomic.AddInt64(&d.size, -1)
+	atomic.AddInt64(&d.version, 1)
+
+	return true
+}
+
+// Clear empties a ConcurrentDict d.
+// Returns true if the dict was actually cleared, otherwise false if nothing was done.
+func (d *ConcurrentDict) Clear() bool {
+	if d.IsEmpty() {
+		return false
+	}
+
+	for _, s := range d.shards {
+		s.mu.Lock()
+		if n := len(s.items); n > 0 {
+			s.items = make(map[uint64]*shardItem)
+			atomic.AddInt64(&d.size, -int64(n))
+		}
+		s.mu.Unlock()
+	}
+	atomic.AddInt64(&d.version, 1)
+
+	return true
+}
+
+// Keys returns a string slice of all dict keys, in insertion order, or nil if dict is
+// empty.
+func (d *ConcurrentDict) Keys() []string {
+	items := d.collect()
+	if items == nil {
+		return nil
+	}
+	keys := make([]string, len(items))
+	for i := range items {
+		keys[i] = items[i].key.Name
+	}
+	return keys
+}
+
+// Values returns a slice of all dict values, in insertion order, or nil if dict is empty.
+func (d *ConcurrentDict) Values() []interface{} {
+	items := d.collect()
+	if items == nil {
+		return nil
+	}
+	values := make([]interface{}, len(items))
+	for i := range items {
+		values[i] = items[i].value
+	}
+	return values
+}
+
+// Items returns a channel of key-value items, in insertion order.
+func (d *ConcurrentDict) Items() <-chan Item {
+	items := d.collect()
+
+	ci := make(chan Item)
+	go func() {
+		defer close(ci)
+		for i := range items {
+			ci <- Item{Key: items[i].key.Name, Value: items[i].value}
+		}
+	}()
+
+	return ci
+}
+
+// collect returns a copy of all items sorted by sequence number, or nil if the dict is
+// empty.
+func (d *ConcurrentDict) collect() []shardItem {
+	if d.IsEmpty() {
+		return nil
+	}
+
+	items := make([]shardItem, 0, d.Len())
+	for _, s := range d.shards {
+		s.mu.RLock()
+		for _, item := range s.items {
+			items = append(items, *item)
+		}
+		s.mu.RUnlock()
+	}
+	if len(items) == 0 {
+		return nil
+	}
+	sort.Slice(items, func(i, j int) bool { return items[i].seq < items[j].seq })
+
+	return items
+}
+
+// Update adds to d the key-value items from iterables, scalars and other dicts, as in
+// Dict.Update().
+// Returns true if any changes were made.
+func (d *ConcurrentDict) Update(vargs ...interface{}) bool {
+	if vargs == nil {
+		return false
+	}
+	ver := d.Version()
+	for i := range vargs {
+		// other dict
+		if other, ok := vargs[i].(itemsSource); ok {
+			for item := range other.Items() {
+				d.Set(item.Key, item.Value)
+			}
+			continue
+		}
+		// iterables and scalars
+		for item := range toIterable(vargs[i]) {
+			if item.Key == nil {
+				item.Key = d.Len()
+			}
+			d.Set(item.Key, item.Value)
+		}
+	}
+	return ver != d.Version()
+}
+
+// String implements the fmt.Stringer interface to print d similar to a Python dict.
+func (d *ConcurrentDict) String() string {
+	items := make([]string, 0, d.Len())
+	for item := range d.Items() {
+		items = append(items, fmt.Sprintf("%v: %#v", item.Key, item.Value))
+	}
+	return "{" + strings.Join(items, ", ") + "}"
+}
+
+// MarshalJSON implements the json.MarshalJSON interface.
+// The JSON representation of a concurrent dict is just a JSON object.
+func (d *ConcurrentDict) MarshalJSON() ([]byte, error) {
+	if d.IsEmpty() {
+		return []byte("null"), nil
+	}
+	return marshalItems(d.Items())
+}
@@ -0,0 +1,97 @@
+// Copyright (c) 2025 srfrog - https://srfrog.dev
+// Use of this source code is governed by the license in the LICENSE file.
+
+package dict
+
+import (
+	"encoding/json"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestConcurrentDict(t *testing.T) {
+	d := NewConcurrent(4)
+	require.Len(t, d.shards, 4)
+	require.Len(t, NewConcurrent(5).shards, 8)
+	require.Len(t, NewConcurrent(0).shards, DefaultShards)
+
+	for i := 0; i < 20; i++ {
+		d.Set(i, i*10)
+	}
+	d.Set("name", "go")
+	d.Set(nil, "ignored")
+
+	require.Equal(t, 21, d.Len())
+	require.Equal(t, 21, d.Version())
+	require.Equal(t, 50, d.Get(5))
+	require.Equal(t, "go", d.Get("name"))
+	require.Equal(t, "alt", d.Get("missing", "alt"))
+	require.True(t, d.Key(19))
+	require.False(t, d.Key(20))
+
+	// Replacing a value keeps the insertion order.
+	d.Set(0, "zero")
+	d.Set(0, "zero")
+	require.Equal(t, 22, d.Version())
+	keys := d.Keys()
+	require.Equal(t, "0", keys[0])
+	require.Equal(t, "name", keys[20])
+	require.Equal(t, "zero", d.Values()[0])
+
+	require.True(t, d.Del(0))
+	require.False(t, d.Del(0))
+	require.Equal(t, "1", d.Keys()[0])
+	d.Set(0, 0)
+	require.Equal(t, "0", d.Keys()[20])
+
+	require.True(t, d.Clear())
+	require.False(t, d.Clear())
+	require.True(t, d.IsEmpty())
+	require.Nil(t, d.Keys())
+	require.Nil(t, d.Values())
+}
+
+func TestConcurrentDictEncoding(t *testing.T) {
+	d := NewConcurrent(2, New().Set("b", 1).Set("a", 2), map[string]int{"c": 3})
+	require.Equal(t, []string{"b", "a", "c"}, d.Keys())
+	require.Equal(t, `{b: 1, a: 2, c: 3}`, d.String())
+
+	b, err := json.Marshal(d)
+	require.NoError(t, err)
+	require.Equal(t, `{"b":1,"a":2,"c":3}`, string(b))
+
+	require.Equal(t, []string{"b", "a", "c"}, New(d).Keys())
+
+	var nd *ConcurrentDict
+	require.True(t, nd.IsEmpty())
+	require.Equal(t, 1, nd.Set("a", 1).Len())
+	b, err = json.Marshal(nd)
+	require.NoError(t, err)
+	require.Equal(t, "null", string(b))
+}
+
+func TestConcurrentDictParallel(t *testing.T) {
+	const workers, n = 8, 200
+
+	d := NewConcurrent(0)
+	var wg sync.WaitGroup
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func(w int) {
+			defer wg.Done()
+			for i := 0; i < n; i++ {
+				d.Set(w*n+i, i)
+				_ = d.Get(i)
+				if i%10 == 0 {
+					_ = d.Keys()
+				}
+			}
+		}(w)
+	}
+	wg.Wait()
+
+	require.Equal(t, workers*n, d.Len())
+	require.Len(t, d.Keys(), workers*n)
+}
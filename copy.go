@@ -0,0 +1,166 @@
+// Copyright (c) 2025 srfrog - https://srfrog.dev
+// Use of this source code is governed by the license in the LICENSE file.
+
+package dict
+
+import (
+	"reflect"
+)
+
+// DeepCopier is implemented by types that know how to make a deep copy of themselves. It's
+// used by DeepCopy for values of custom types.
+type DeepCopier interface {
+	DeepCopy() interface{}
+}
+
+// SetDefault gets the value of key if found, otherwise it adds an item with value, as one
+// atomic operation. This is like Python's dict.setdefault().
+// Returns the value of key in dict.
+func (d *Dict) SetDefault(key, value interface{}) interface{} {
+	v, _ := d.LoadOrStore(key, value)
+	return v
+}
+
+// FromKeys returns a new dict with the keys of an iterable, and all set to value. The keys
+// of maps and dicts are used, and the values of slices, arrays and channels. This is like
+// Python's dict.fromkeys(), so value is shared by all items and not copied.
+func FromKeys(keys interface{}, value interface{}) *Dict {
+	d := New()
+	if other, ok := keys.(itemsSource); ok {
+		for item := range other.Items() {
+			d.Set(item.Key, value)
+		}
+		return d
+	}
+	for item := range toIterable(keys) {
+		if item.Key == nil {
+			item.Key = item.Value
+		}
+		d.Set(item.Key, value)
+	}
+	return d
+}
+
+// Copy returns a shallow copy of d, with the same key order and values. The values are not
+// copied, so embedded dicts, slices and maps are shared with d.
+func (d *Dict) Copy() *Dict {
+	if d == nil {
+		return New()
+	}
+
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+
+	return d.copyItems(nil)
+}
+
+// DeepCopy returns a deep copy of d. Embedded dicts, slices, arrays and maps are copied
+// recursively, and values that implement DeepCopier are copied with their DeepCopy
+// method. Other values, like pointers and structs, are copied as is.
+func (d *Dict) DeepCopy() *Dict {
+	if d == nil {
+		return New()
+	}
+
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+
+	return d.copyItems(deepCopyValue)
+}
+
+// copyItems returns a new dict with the items of d. If fn is not nil, the values are copied
+// with it. The caller must hold the lock.
+func (d *Dict) copyItems(fn func(v interface{}) interface{}) *Dict {
+	c := &Dict{
+		size:    int64(len(d.keys)),
+		keys:    append([]Key(nil), d.keys...),
+		values:  make(map[uint64]interface{}, len(d.values)),
+		factory: d.factory,
+		hasher:  d.hasher,
+	}
+	for id, v := range d.values {
+		if fn != nil {
+			v = fn(v)
+		}
+		c.values[id] = v
+	}
+	return c
+}
+
+// deepCopyValue returns a deep copy of v, as described in DeepCopy.
+func deepCopyValue(v interface{}) interface{} {
+	switch x := v.(type) {
+	case nil:
+		return nil
+	case DeepCopier:
+		return x.DeepCopy()
+	case *Dict:
+		if x == nil {
+			return x
+		}
+		return x.DeepCopy()
+	}
+
+	rv := reflect.ValueOf(v)
+	switch rv.Kind() {
+	case reflect.Slice:
+		if rv.IsNil() {
+			return v
+		}
+		c := reflect.MakeSlice(rv.Type(), rv.Len(), rv.Len())
+		reflect.Copy(c, rv)
+		if hasRefs(rv.Type().Elem()) {
+			for i := 0; i < rv.Len(); i++ {
+				c.Index(i).Set(deepCopyReflect(rv.Index(i)))
+			}
+		}
+		return c.Interface()
+
+	case reflect.Array:
+		c := reflect.New(rv.Type()).Elem()
+		reflect.Copy(c, rv)
+		if hasRefs(rv.Type().Elem()) {
+			for i := 0; i < rv.Len(); i++ {
+				c.Index(i).Set(deepCopyReflect(rv.Index(i)))
+			}
+		}
+		return c.Interface()
+
+	case reflect.Map:
+		if rv.IsNil() {
+			return v
+		}
+		c := reflect.MakeMapWithSize(rv.Type(), rv.Len())
+		for iter := rv.MapRange(); iter.Next(); {
+			c.SetMapIndex(iter.Key(), deepCopyReflect(iter.Value()))
+		}
+		return c.Interface()
+	}
+
+	return v
+}
+
+// deepCopyReflect returns a deep copy of v, with the same type as v.
+func deepCopyReflect(v reflect.Value) reflect.Value {
+	if isNillable(v.Kind()) && v.IsNil() {
+		return v
+	}
+
+	c := deepCopyValue(v.Interface())
+	if c == nil {
+		return reflect.Zero(v.Type())
+	}
+	if rc := reflect.ValueOf(c); rc.Type().AssignableTo(v.Type()) {
+		return rc
+	}
+	return v
+}
+
+// hasRefs returns true if values of type t can hold references that DeepCopy copies.
+func hasRefs(t reflect.Type) bool {
+	switch t.Kind() {
+	case reflect.Interface, reflect.Ptr, reflect.Slice, reflect.Map, reflect.Array:
+		return true
+	}
+	return t.Implements(reflect.TypeOf((*DeepCopier)(nil)).Elem())
+}
@@ -0,0 +1,123 @@
+// Copyright (c) 2025 srfrog - https://srfrog.dev
+// Use of this source code is governed by the license in the LICENSE file.
+
+package dict
+
+import (
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+type testCopier struct {
+	n *int
+}
+
+func (c testCopier) DeepCopy() interface{} {
+	n := *c.n
+	return testCopier{n: &n}
+}
+
+func TestSetDefault(t *testing.T) {
+	d := New().Set("a", 1)
+
+	require.Equal(t, 1, d.SetDefault("a", 2))
+	require.Equal(t, 2, d.SetDefault("b", 2))
+	require.Equal(t, []string{"a", "b"}, d.Keys())
+
+	// Only one of many concurrent calls sets the value.
+	var stored int64
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			if d.SetDefault("c", i) == i {
+				atomic.AddInt64(&stored, 1)
+			}
+		}(i)
+	}
+	wg.Wait()
+	require.EqualValues(t, 1, stored)
+}
+
+func TestFromKeys(t *testing.T) {
+	tests := []struct {
+		in   interface{}
+		keys []string
+	}{
+		{in: []string{"a", "b", "a"}, keys: []string{"a", "b"}},
+		{in: [2]int{3, 1}, keys: []string{"3", "1"}},
+		{in: map[string]int{"x": 1}, keys: []string{"x"}},
+		{in: New().Set("k", 1).Set("j", 2), keys: []string{"k", "j"}},
+		{in: nil, keys: nil},
+	}
+	for _, tc := range tests {
+		d := FromKeys(tc.in, 0)
+		require.Equal(t, tc.keys, d.Keys())
+		for _, v := range d.Values() {
+			require.Equal(t, 0, v)
+		}
+	}
+}
+
+func TestCopy(t *testing.T) {
+	d := NewDefault(func(string) interface{} { return 0 })
+	d.Set("b", []int{1}).Set("a", New().Set("x", 1))
+
+	c := d.Copy()
+	require.Equal(t, []string{"b", "a"}, c.Keys())
+	require.Equal(t, 0, c.Get("missing"))
+
+	// Values are shared.
+	c.Get("a").(*Dict).Set("y", 2)
+	require.True(t, d.Get("a").(*Dict).Key("y"))
+
+	c.Set("z", 3)
+	require.False(t, d.Key("z"))
+
+	var nd *Dict
+	require.True(t, nd.Copy().IsEmpty())
+	require.True(t, nd.DeepCopy().IsEmpty())
+
+	// Copies of empty dicts keep the factory and hasher.
+	empty := NewDefault(func(string) interface{} { return 0 })
+	for _, c := range []*Dict{empty.Copy(), empty.DeepCopy()} {
+		require.Equal(t, 0, c.Get("missing"))
+	}
+	h := NewSipHasher(1, 2)
+	for _, c := range []*Dict{NewWithHasher(h).Copy(), NewWithHasher(h).DeepCopy()} {
+		k := c.Set("a", 1).keys[0]
+		require.Equal(t, h([]byte("a")), k.ID)
+	}
+}
+
+func TestDeepCopy(t *testing.T) {
+	n := 1
+	d := New().
+		Set("dict", New().Set("x", []int{1, 2})).
+		Set("list", []interface{}{New().Set("y", 1), "s", nil}).
+		Set("map", map[string][]string{"k": {"v"}}).
+		Set("array", [1][]int{{1}}).
+		Set("copier", testCopier{n: &n}).
+		Set("nil", []int(nil))
+
+	c := d.DeepCopy()
+	require.True(t, d.Equal(c))
+	require.Equal(t, d.Keys(), c.Keys())
+
+	c.Get("dict").(*Dict).Get("x").([]int)[0] = 10
+	c.Get("list").([]interface{})[0].(*Dict).Set("y", 2)
+	c.Get("map").(map[string][]string)["k"][0] = "w"
+	c.Get("array").([1][]int)[0][0] = 10
+	*c.Get("copier").(testCopier).n = 10
+
+	require.Equal(t, []int{1, 2}, d.Get("dict").(*Dict).Get("x"))
+	require.Equal(t, 1, d.Get("list").([]interface{})[0].(*Dict).Get("y"))
+	require.Equal(t, "v", d.Get("map").(map[string][]string)["k"][0])
+	require.Equal(t, 1, d.Get("array").([1][]int)[0][0])
+	require.Equal(t, 1, n)
+	require.Nil(t, c.Get("nil"))
+}
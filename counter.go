@@ -0,0 +1,210 @@
+// Copyright (c) 2025 srfrog - https://srfrog.dev
+// Use of this source code is governed by the license in the LICENSE file.
+
+package dict
+
+import (
+	"reflect"
+	"sync/atomic"
+)
+
+// Counter is a dict for counting hashable keys, like Python collections.Counter. The
+// counts are int values, which can be zero or negative. All counter operations are
+// goroutine safe and atomic for each key.
+// Use NewCounter to create a Counter.
+type Counter struct {
+	d *Dict
+}
+
+// NewCounter returns a new Counter object. vargs can be scalars, slices, arrays and
+// channels, and each element is counted as a key. Maps, dicts and counters are mappings
+// of keys to counts, as in Python, and their counts are added; values that are not
+// numbers are ignored.
+func NewCounter(vargs ...interface{}) *Counter {
+	c := &Counter{d: New()}
+	for i := range vargs {
+		// other dict or counter
+		if other, ok := vargs[i].(itemsSource); ok {
+			for item := range other.Items() {
+				c.addCount(item)
+			}
+			continue
+		}
+		// maps
+		if reflect.ValueOf(vargs[i]).Kind() == reflect.Map {
+			for item := range toIterable(vargs[i]) {
+				c.addCount(item)
+			}
+			continue
+		}
+		// iterables and scalars
+		for item := range toIterable(vargs[i]) {
+			c.Increment(item.Value)
+		}
+	}
+	return c
+}
+
+// addCount adds the count in item.Value to the key item.Key, if the count is a number.
+func (c *Counter) addCount(item Item) {
+	if v := reflect.ValueOf(item.Value); isNumber(v) {
+		c.Add(item.Key, int(toNumber(v)))
+	}
+}
+
+// Add adds n to the count of key, which can be negative to subtract.
+// Returns the new count of key, or zero (0) if key is not a valid key type.
+func (c *Counter) Add(key interface{}, n int) int {
+	c.d.mu.Lock()
+	defer c.d.mu.Unlock()
+
+	k, ok := c.d.makeKey(key)
+	if !ok {
+		return 0
+	}
+
+	if v, ok := c.d.values[k.ID]; ok {
+		count, _ := v.(int)
+		count += n
+		c.d.values[k.ID] = count
+		if n != 0 {
+			atomic.AddInt64(&c.d.version, 1)
+		}
+		return count
+	}
+	c.d.insertKey(k, n)
+
+	return n
+}
+
+// Increment adds one (1) to the count of key.
+// Returns the new count of key.
+func (c *Counter) Increment(key interface{}) int {
+	return c.Add(key, 1)
+}
+
+// Count returns the count of key, or zero (0) if key is not in the counter.
+func (c *Counter) Count(key interface{}) int {
+	count, _ := c.d.Get(key, 0).(int)
+	return count
+}
+
+// Del removes key from the counter.
+// Returns true if key is found and removed, false otherwise.
+func (c *Counter) Del(key interface{}) bool {
+	return c.d.Del(key)
+}
+
+// Len returns the number of keys in the counter.
+func (c *Counter) Len() int {
+	return c.d.Len()
+}
+
+// Keys returns a string slice of all the keys counted, in insertion order.
+func (c *Counter) Keys() []string {
+	return c.d.Keys()
+}
+
+// Items returns a channel of the key-count items, in insertion order.
+func (c *Counter) Items() <-chan Item {
+	return c.d.Items()
+}
+
+// Total returns the sum of all counts.
+func (c *Counter) Total() int {
+	var total int
+	for _, v := range c.d.Values() {
+		count, _ := v.(int)
+		total += count
+	}
+	return total
+}
+
+// MostCommon returns the n items with the highest counts, from the most common to the
+// least. Items with equal counts are in insertion order. If n is zero or negative, all
+// the items are returned.
+func (c *Counter) MostCommon(n int) []Item {
+	items := c.d.SortedItems(ByValue, Descending)
+	if n > 0 && n < len(items) {
+		items = items[:n]
+	}
+	return items
+}
+
+// Elements returns the keys repeated as many times as their count, in insertion order.
+// Keys with a count less than one (1) are ignored.
+func (c *Counter) Elements() []string {
+	var elems []string
+	for item := range c.d.Items() {
+		count, _ := item.Value.(int)
+		for i := 0; i < count; i++ {
+			elems = append(elems, item.Key.(string))
+		}
+	}
+	return elems
+}
+
+// Plus returns a new counter with the counts of c and other added, like Python c + other.
+// Only positive counts are kept.
+func (c *Counter) Plus(other *Counter) *Counter {
+	return c.combine(other, func(a, b int) int { return a + b })
+}
+
+// Minus returns a new counter with the counts of other subtracted from the counts of c,
+// like Python c - other. Only positive counts are kept.
+func (c *Counter) Minus(other *Counter) *Counter {
+	return c.combine(other, func(a, b int) int { return a - b })
+}
+
+// Intersect returns a new counter with the minimum of the counts in c and other, like
+// Python c & other. Only positive counts are kept.
+func (c *Counter) Intersect(other *Counter) *Counter {
+	return c.combine(other, func(a, b int) int {
+		if a < b {
+			return a
+		}
+		return b
+	})
+}
+
+// UnionMax returns a new counter with the maximum of the counts in c and other, like
+// Python c | other. Only positive counts are kept.
+func (c *Counter) UnionMax(other *Counter) *Counter {
+	return c.combine(other, func(a, b int) int {
+		if a > b {
+			return a
+		}
+		return b
+	})
+}
+
+// combine returns a new counter with the positive results of fn for the counts of each key
+// in c and other. The keys of c are first, followed by the new keys in other.
+func (c *Counter) combine(other *Counter, fn func(a, b int) int) *Counter {
+	res := NewCounter()
+	for item := range c.d.Items() {
+		if count := fn(c.Count(item.Key), other.Count(item.Key)); count > 0 {
+			res.Add(item.Key, count)
+		}
+	}
+	for item := range other.d.Items() {
+		if c.d.Key(item.Key) {
+			continue
+		}
+		if count := fn(0, other.Count(item.Key)); count > 0 {
+			res.Add(item.Key, count)
+		}
+	}
+	return res
+}
+
+// String implements the fmt.Stringer interface to print c similar to a Python dict.
+func (c *Counter) String() string {
+	return c.d.String()
+}
+
+// MarshalJSON implements the json.MarshalJSON interface.
+// The JSON representation of a counter is a JSON object of counts.
+func (c *Counter) MarshalJSON() ([]byte, error) {
+	return c.d.MarshalJSON()
+}
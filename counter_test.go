@@ -0,0 +1,109 @@
+// Copyright (c) 2025 srfrog - https://srfrog.dev
+// Use of this source code is governed by the license in the LICENSE file.
+
+package dict
+
+import (
+	"encoding/json"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCounter(t *testing.T) {
+	c := NewCounter(strings.Split("the quick brown fox jumps over the lazy dog the end", " "))
+
+	require.Equal(t, 3, c.Count("the"))
+	require.Equal(t, 1, c.Count("fox"))
+	require.Equal(t, 0, c.Count("cat"))
+	require.Equal(t, 9, c.Len())
+	require.Equal(t, 11, c.Total())
+
+	require.Equal(t, 2, c.Increment("fox"))
+	require.Equal(t, 5, c.Add("the", 2))
+	require.Equal(t, -1, c.Add("cat", -1))
+	require.Equal(t, 0, c.Add(nil, 1))
+	require.Equal(t, 13, c.Total())
+
+	require.Equal(t, []Item{{Key: "the", Value: 5}, {Key: "fox", Value: 2}}, c.MostCommon(2))
+	require.Len(t, c.MostCommon(0), 10)
+	require.Equal(t, Item{Key: "cat", Value: -1}, c.MostCommon(-1)[9])
+
+	require.True(t, c.Del("cat"))
+	require.Equal(t, "{the: 5, quick: 1, brown: 1, fox: 2, jumps: 1, over: 1, lazy: 1, dog: 1, end: 1}", c.String())
+
+	b, err := json.Marshal(c)
+	require.NoError(t, err)
+	require.JSONEq(t, `{"the": 5, "quick": 1, "brown": 1, "fox": 2, "jumps": 1, "over": 1, "lazy": 1, "dog": 1, "end": 1}`, string(b))
+}
+
+func TestCounterElements(t *testing.T) {
+	c := NewCounter("a", "b", "a", 1)
+	c.Add("z", 0)
+	c.Add("y", -2)
+
+	require.Equal(t, []string{"a", "a", "b", "1"}, c.Elements())
+	require.Equal(t, []string{"a", "b", "1", "z", "y"}, c.Keys())
+}
+
+func TestCounterMapping(t *testing.T) {
+	c := NewCounter(map[string]int{"a": 2, "b": 1})
+	require.Equal(t, 2, c.Count("a"))
+	require.Equal(t, 3, c.Total())
+
+	// Dicts and counters are also mappings of keys to counts.
+	c = NewCounter(New().Set("a", 3).Set("b", "x").Set("c", 1.0), c, "a")
+	require.Equal(t, []string{"a", "c", "b"}, c.Keys())
+	require.Equal(t, 6, c.Count("a"))
+	require.Equal(t, 1, c.Count("b"))
+	require.Equal(t, 1, c.Count("c"))
+	require.Equal(t, 0, c.Count("x"))
+}
+
+func TestCounterArithmetic(t *testing.T) {
+	a := NewCounter([]string{"x", "x", "x", "y", "z"})
+	b := NewCounter([]string{"x", "y", "y", "w"})
+
+	tests := []struct {
+		name string
+		out  *Counter
+		json string
+	}{
+		{"plus", a.Plus(b), `{"x": 4, "y": 3, "z": 1, "w": 1}`},
+		{"minus", a.Minus(b), `{"x": 2, "z": 1}`},
+		{"intersect", a.Intersect(b), `{"x": 1, "y": 1}`},
+		{"union max", a.UnionMax(b), `{"x": 3, "y": 2, "z": 1, "w": 1}`},
+	}
+	for _, tc := range tests {
+		b, err := json.Marshal(tc.out)
+		require.NoError(t, err)
+		require.JSONEq(t, tc.json, string(b), tc.name)
+	}
+
+	require.Equal(t, []string{"x", "y", "z", "w"}, a.Plus(b).Keys())
+	require.Equal(t, 5, a.Total())
+}
+
+func TestCounterConcurrent(t *testing.T) {
+	c := NewCounter()
+
+	var wg sync.WaitGroup
+	numWorkers, numAdds := 8, 1000
+
+	wg.Add(numWorkers)
+	for i := 0; i < numWorkers; i++ {
+		go func() {
+			defer wg.Done()
+			for j := 0; j < numAdds; j++ {
+				c.Increment(j % 10)
+			}
+		}()
+	}
+	wg.Wait()
+
+	require.Equal(t, 10, c.Len())
+	require.Equal(t, numWorkers*numAdds, c.Total())
+	require.Equal(t, numWorkers*numAdds/10, c.Count(3))
+}
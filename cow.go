@@ -0,0 +1,296 @@
+// Copyright (c) 2025 srfrog - https://srfrog.dev
+// Use of this source code is governed by the license in the LICENSE file.
+
+package dict
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"sync"
+	"sync/atomic"
+)
+
+// COWDict is a copy-on-write dict for read-heavy workloads. Readers load the current
+// state of the dict atomically, without locking, so they never wait for a writer.
+// Writers copy the state, change the copy and then publish it, so every write costs a
+// copy of the dict.
+// Use NewCOW to create a COWDict.
+type COWDict struct {
+	state atomic.Value // *cowState
+	mu    sync.Mutex   // serializes writers
+}
+
+// cowState is an immutable state of a COWDict. It's never changed after it's published.
+type cowState struct {
+	version int
+	keys    []Key
+	values  map[uint64]interface{}
+}
+
+// NewCOW returns a new COWDict object.
+// vargs are the initial values, as in New().
+func NewCOW(vargs ...interface{}) *COWDict {
+	d := &COWDict{}
+	d.state.Store(&cowState{values: make(map[uint64]interface{})})
+	d.Update(vargs...)
+	return d
+}
+
+// load returns the current state of d.
+func (d *COWDict) load() *cowState {
+	if d == nil {
+		return &cowState{}
+	}
+	if s, ok := d.state.Load().(*cowState); ok {
+		return s
+	}
+	return &cowState{}
+}
+
+// write calls fn with a copy of the current state, and publishes the copy if fn made any
+// changes. fn returns the number of changes made.
+func (d *COWDict) write(fn func(s *cowState) int) bool {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	curr := d.load()
+	s := &cowState{
+		version: curr.version,
+		keys:    append(make([]Key, 0, len(curr.keys)+1), curr.keys...),
+		values:  make(map[uint64]interface{}, len(curr.values)+1),
+	}
+	for id, v := range curr.values {
+		s.values[id] = v
+	}
+
+	n := fn(s)
+	if n == 0 {
+		return false
+	}
+	s.version += n
+	d.state.Store(s)
+
+	return true
+}
+
+// set adds or replaces an item in s.
+// Returns 1 if s was changed, 0 otherwise.
+func (s *cowState) set(k *Key, value interface{}) int {
+	if curr, ok := s.values[k.ID]; ok {
+		s.values[k.ID] = value
+		if reflect.DeepEqual(value, curr) {
+			return 0
+		}
+		return 1
+	}
+	s.keys = append(s.keys, *k)
+	s.values[k.ID] = value
+	return 1
+}
+
+// Version returns the version of the dictionary. The version is increased after every
+// change to dict items.
+// Returns version, which is zero (0) initially.
+func (d *COWDict) Version() int {
+	return d.load().version
+}
+
+// Len returns the size of a COWDict.
+func (d *COWDict) Len() int {
+	return len(d.load().keys)
+}
+
+// IsEmpty returns true if the dict is empty, false otherwise.
+func (d *COWDict) IsEmpty() bool {
+	return d.Len() == 0
+}
+
+// Set inserts a new item into the dict. If a value matching the key already exists,
+// its value is replaced, otherwise a new item is added.
+func (d *COWDict) Set(key, value interface{}) *COWDict {
+	// Sanity: don't panic on nil dict, just create a new one.
+	if d == nil {
+		d = NewCOW()
+	}
+
+	k := MakeKey(key)
+	if k == nil {
+		return d
+	}
+	d.write(func(s *cowState) int {
+		return s.set(k, value)
+	})
+
+	return d
+}
+
+// Get retrieves an item from dict by key, without locking. If alt value is passed, it
+// will be used as default value if no item is found.
+// Returns a value matching key in dict, otherwise nil or alt if given.
+func (d *COWDict) Get(key interface{}, alt ...interface{}) interface{} {
+	if id, ok := hashKey(key, nil); ok {
+		if v, ok := d.load().values[id]; ok {
+			return v
+		}
+	}
+	if alt != nil {
+		return alt[0]
+	}
+	return nil
+}
+
+// Key returns true if key is in dict d, false otherwise.
+func (d *COWDict) Key(key interface{}) bool {
+	id, ok := hashKey(key, nil)
+	if !ok {
+		return false
+	}
+	_, ok = d.load().values[id]
+	return ok
+}
+
+// Del removes an item from dict by key name.
+// Returns true if an item is found and removed, false otherwise.
+func (d *COWDict) Del(key interface{}) bool {
+	k := MakeKey(key)
+	if k == nil || !d.Key(key) {
+		return false
+	}
+
+	return d.write(func(s *cowState) int {
+		if _, ok := s.values[k.ID]; !ok {
+			return 0
+		}
+		delete(s.values, k.ID)
+		for i := range s.keys {
+			if s.keys[i].ID == k.ID {
+				s.keys = append(s.keys[:i], s.keys[i+1:]...)
+				break
+			}
+		}
+		return 1
+	})
+}
+
+// Clear empties a COWDict d.
+// Returns true if the dict was actually cleared, otherwise false if nothing was done.
+func (d *COWDict) Clear() bool {
+	if d.IsEmpty() {
+		return false
+	}
+
+	return d.write(func(s *cowState) int {
+		if len(s.keys) == 0 {
+			return 0
+		}
+		s.keys = nil
+		s.values = make(map[uint64]interface{})
+		return 1
+	})
+}
+
+// Keys returns a string slice of all dict keys, or nil if dict is empty.
+func (d *COWDict) Keys() []string {
+	s := d.load()
+	if len(s.keys) == 0 {
+		return nil
+	}
+	keys := make([]string, len(s.keys))
+	for i := range s.keys {
+		keys[i] = s.keys[i].Name
+	}
+	return keys
+}
+
+// Values returns a slice of all dict values, or nil if dict is empty.
+func (d *COWDict) Values() []interface{} {
+	s := d.load()
+	if len(s.keys) == 0 {
+		return nil
+	}
+	values := make([]interface{}, len(s.keys))
+	for i, key := range s.keys {
+		values[i] = s.values[key.ID]
+	}
+	return values
+}
+
+// Items returns a channel of key-value items. The items are from the state of the dict
+// when Items is called, later changes are not seen.
+func (d *COWDict) Items() <-chan Item {
+	s := d.load()
+
+	ci := make(chan Item)
+	go func() {
+		defer close(ci)
+		for _, key := range s.keys {
+			ci <- Item{Key: key.Name, Value: s.values[key.ID]}
+		}
+	}()
+
+	return ci
+}
+
+// Update adds to d the key-value items from iterables, scalars and other dicts, as in
+// Dict.Update(). All the changes are published at once, so readers see either none or
+// all of them.
+// Returns true if any changes were made.
+func (d *COWDict) Update(vargs ...interface{}) bool {
+	if vargs == nil {
+		return false
+	}
+
+	// Read other dicts before locking, in case one of them is d.
+	var items []Item
+	for i := range vargs {
+		// other dict
+		if other, ok := vargs[i].(itemsSource); ok {
+			for item := range other.Items() {
+				items = append(items, item)
+			}
+			continue
+		}
+		// iterables and scalars
+		for item := range toIterable(vargs[i]) {
+			items = append(items, item)
+		}
+	}
+
+	return d.write(func(s *cowState) int {
+		var n int
+		for _, item := range items {
+			if item.Key == nil {
+				item.Key = len(s.keys)
+			}
+			if k := MakeKey(item.Key); k != nil {
+				n += s.set(k, item.Value)
+			}
+		}
+		return n
+	})
+}
+
+// Snapshot returns a consistent point-in-time read-only copy of d.
+func (d *COWDict) Snapshot() *FrozenDict {
+	s := d.load()
+	return freeze(s.keys, s.values, nil)
+}
+
+// String implements the fmt.Stringer interface to print d similar to a Python dict.
+func (d *COWDict) String() string {
+	items := make([]string, 0, d.Len())
+	for item := range d.Items() {
+		items = append(items, fmt.Sprintf("%v: %#v", item.Key, item.Value))
+	}
+	return "{" + strings.Join(items, ", ") + "}"
+}
+
+// MarshalJSON implements the json.MarshalJSON interface.
+// The JSON representation of a COW dict is just a JSON object.
+func (d *COWDict) MarshalJSON() ([]byte, error) {
+	if d.IsEmpty() {
+		return []byte("null"), nil
+	}
+	return marshalItems(d.Items())
+}
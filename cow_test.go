@@ -0,0 +1,102 @@
+// Copyright (c) 2025 srfrog - https://srfrog.dev
+// Use of this source code is governed by the license in the LICENSE file.
+
+package dict
+
+import (
+	"encoding/json"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCOWDict(t *testing.T) {
+	d := NewCOW(map[string]int{"a": 1})
+	require.Equal(t, 1, d.Version())
+
+	d.Set("b", 2).Set("c", 3).Set(nil, 4)
+	require.Equal(t, 3, d.Len())
+	require.Equal(t, 3, d.Version())
+	require.Equal(t, []string{"a", "b", "c"}, d.Keys())
+	require.Equal(t, []interface{}{1, 2, 3}, d.Values())
+	require.Equal(t, 2, d.Get("b"))
+	require.Equal(t, "alt", d.Get("missing", "alt"))
+	require.True(t, d.Key("c"))
+	require.False(t, d.Key("missing"))
+
+	d.Set("b", 2)
+	require.Equal(t, 3, d.Version())
+
+	snap := d.Snapshot()
+	items := d.Items()
+
+	require.True(t, d.Del("b"))
+	require.False(t, d.Del("b"))
+	require.Equal(t, `{a: 1, c: 3}`, d.String())
+
+	// Snapshots and item channels don't see later changes.
+	require.Equal(t, []string{"a", "b", "c"}, snap.Keys())
+	var keys []interface{}
+	for item := range items {
+		keys = append(keys, item.Key)
+	}
+	require.Equal(t, []interface{}{"a", "b", "c"}, keys)
+
+	b, err := json.Marshal(d)
+	require.NoError(t, err)
+	require.Equal(t, `{"a":1,"c":3}`, string(b))
+
+	require.True(t, d.Clear())
+	require.False(t, d.Clear())
+	require.Nil(t, d.Keys())
+	require.Nil(t, d.Values())
+
+	var nd *COWDict
+	require.True(t, nd.IsEmpty())
+	require.Nil(t, nd.Get("a"))
+	require.Equal(t, 1, nd.Set("a", 1).Len())
+}
+
+func TestCOWDictUpdate(t *testing.T) {
+	d := NewCOW(New().Set("a", 1))
+
+	ver := d.Version()
+	require.True(t, d.Update([]string{"x", "y"}, New().Set("a", 2).Set("b", 3)))
+	require.Equal(t, ver+4, d.Version())
+	require.Equal(t, []string{"a", "1", "2", "b"}, d.Keys())
+	require.False(t, d.Update(New().Set("a", 2)))
+	require.False(t, d.Update())
+
+	// Update with itself doesn't deadlock.
+	require.False(t, d.Update(d))
+	require.Equal(t, d.Keys(), New(d).Keys())
+}
+
+func TestCOWDictConcurrent(t *testing.T) {
+	const n = 100
+
+	d := NewCOW()
+	var mismatch int
+	var wg sync.WaitGroup
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+		for i := 0; i < n; i++ {
+			d.Update(map[string]int{"x": i, "y": i})
+		}
+	}()
+	go func() {
+		defer wg.Done()
+		for i := 0; i < n; i++ {
+			// Both keys are always from the same write.
+			if snap := d.Snapshot(); snap.Get("x") != snap.Get("y") {
+				mismatch++
+			}
+		}
+	}()
+	wg.Wait()
+
+	require.Zero(t, mismatch)
+	require.Equal(t, n-1, d.Get("x"))
+}
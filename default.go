@@ -0,0 +1,47 @@
+// Copyright (c) 2025 srfrog - https://srfrog.dev
+// Use of this source code is governed by the license in the LICENSE file.
+
+package dict
+
+// NewDefault returns a new Dict object that behaves like Python defaultdict. When Get is
+// called without an alt value for a key that is not in the dict, the value returned by
+// factory for the key name is added to the dict and returned. The check and insert are
+// atomic, so concurrent callers always get the same value. The factory is called with the
+// dict locked, so it must not call methods of the dict.
+// vargs are the initial values, as in New().
+//
+//	groups := dict.NewDefault(func(string) interface{} { return new([]string) })
+//	list := groups.Get("fruits").(*[]string)
+//	*list = append(*list, "apple")
+func NewDefault(factory func(key string) interface{}, vargs ...interface{}) *Dict {
+	d := New(vargs...)
+	d.factory = factory
+	return d
+}
+
+// getDefault gets the value of key, adding the factory value if not found.
+func (d *Dict) getDefault(key interface{}) interface{} {
+	d.mu.RLock()
+	id, ok := d.keyID(key)
+	value, found := d.values[id]
+	d.mu.RUnlock()
+	if !ok {
+		return nil
+	}
+	if found {
+		return value
+	}
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	// Check again, another writer might have added it.
+	k, _ := d.makeKey(key)
+	if value, ok := d.values[k.ID]; ok {
+		return value
+	}
+	value = d.factory(k.Name)
+	d.insertKey(k, value)
+
+	return value
+}
@@ -0,0 +1,61 @@
+// Copyright (c) 2025 srfrog - https://srfrog.dev
+// Use of this source code is governed by the license in the LICENSE file.
+
+package dict
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewDefault(t *testing.T) {
+	var calls int
+	d := NewDefault(func(key string) interface{} {
+		calls++
+		return "default " + key
+	}, map[string]string{"a": "x"})
+
+	require.Equal(t, "x", d.Get("a"))
+	require.Equal(t, "default b", d.Get("b"))
+	require.Equal(t, "default b", d.Get("b"))
+	require.Equal(t, 1, calls)
+	require.Equal(t, []string{"a", "b"}, d.Keys())
+
+	// Alt values and invalid keys don't add items.
+	require.Equal(t, "alt", d.Get("c", "alt"))
+	require.Nil(t, d.Get(nil))
+	require.False(t, d.Key("c"))
+	require.Equal(t, 2, d.Len())
+}
+
+func TestNewDefaultConcurrent(t *testing.T) {
+	d := NewDefault(func(string) interface{} { return new(int) })
+
+	var (
+		wg sync.WaitGroup
+		mu sync.Mutex
+	)
+	numWorkers, numKeys := 8, 50
+
+	wg.Add(numWorkers)
+	for i := 0; i < numWorkers; i++ {
+		go func() {
+			defer wg.Done()
+			for j := 0; j < numKeys; j++ {
+				p := d.Get(fmt.Sprintf("key%d", j)).(*int)
+				mu.Lock()
+				*p++
+				mu.Unlock()
+			}
+		}()
+	}
+	wg.Wait()
+
+	require.Equal(t, numKeys, d.Len())
+	for _, v := range d.Values() {
+		require.Equal(t, numWorkers, *v.(*int))
+	}
+}
@@ -14,8 +14,10 @@ import (
 // Dict is a type that uses a hash mapping index, also known as a dictionary.
 type Dict struct {
 	size, version int64
-	keys          []*Key
+	keys          []Key
 	values        map[uint64]interface{}
+	factory       func(key string) interface{}
+	hasher        Hasher
 	mu            sync.RWMutex
 }
 
@@ -40,6 +42,66 @@ func New(vargs ...interface{}) *Dict {
 	return d
 }
 
+// NewWithCapacity returns a new empty Dict object with space for at least n items, so
+// that adding them doesn't need to grow the dict.
+func NewWithCapacity(n int) *Dict {
+	if n < 0 {
+		n = 0
+	}
+	return &Dict{
+		keys:   make([]Key, 0, n),
+		values: make(map[uint64]interface{}, n),
+	}
+}
+
+// Grow makes space in d for at least n more items, so that adding them doesn't need to
+// grow the dict. The key order is grown geometrically, so calling Grow before every
+// insert is cheap.
+func (d *Dict) Grow(n int) {
+	if d == nil || n <= 0 {
+		return
+	}
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	d.grow(n)
+}
+
+// grow makes space in d for n more items. The caller must hold the lock.
+func (d *Dict) grow(n int) {
+	if len(d.values) == 0 {
+		// An empty map can be replaced with one sized for the items.
+		d.values = make(map[uint64]interface{}, len(d.keys)+n)
+	}
+	if cap(d.keys)-len(d.keys) >= n {
+		return
+	}
+	size := 2 * cap(d.keys)
+	if size < len(d.keys)+n {
+		size = len(d.keys) + n
+	}
+	d.keys = append(make([]Key, 0, size), d.keys...)
+}
+
+// Compact releases the memory left unused by removed items. Go maps don't shrink, so
+// after many items are removed the dict can hold much more memory than it needs.
+func (d *Dict) Compact() {
+	if d == nil {
+		return
+	}
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	d.keys = append(make([]Key, 0, len(d.keys)), d.keys...)
+	values := make(map[uint64]interface{}, len(d.values))
+	for id, v := range d.values {
+		values[id] = v
+	}
+	d.values = values
+}
+
 // Set inserts a new item into the dict. If a value matching the key already exists,
 // its value is replaced, otherwise a new item is added.
 func (d *Dict) Set(key, value interface{}) *Dict {
@@ -48,45 +110,66 @@ func (d *Dict) Set(key, value interface{}) *Dict {
 		d = New()
 	}
 
-	k := MakeKey(key)
-	if k == nil {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	id, ok := d.keyID(key)
+	if !ok {
 		return d
 	}
 
-	d.mu.Lock()
-	defer d.mu.Unlock()
+	// The key name is only needed for new items.
+	if !d.replaceValue(id, value) {
+		k, _ := d.makeKey(key)
+		d.insertKey(k, value)
+	}
 
-	if curr, ok := d.values[k.ID]; ok {
-		d.values[k.ID] = value
+	return d
+}
 
-		// Value changed, update version.
-		if !reflect.DeepEqual(value, curr) {
-			atomic.AddInt64(&d.version, 1)
-		}
+// setKey inserts or replaces the item of k in d. The caller must hold the lock.
+func (d *Dict) setKey(k Key, value interface{}) {
+	if !d.replaceValue(k.ID, value) {
+		d.insertKey(k, value)
+	}
+}
 
-		return d
+// replaceValue replaces the value of the item with ID id, if found. The caller must hold
+// the lock.
+// Returns true if the item was found, false otherwise.
+func (d *Dict) replaceValue(id uint64, value interface{}) bool {
+	curr, ok := d.values[id]
+	if !ok {
+		return false
 	}
+	d.values[id] = value
+
+	// Value changed, update version.
+	if !reflect.DeepEqual(value, curr) {
+		atomic.AddInt64(&d.version, 1)
+	}
+
+	return true
+}
+
+// insertKey adds a new item at the end of d. The caller must hold the lock.
+func (d *Dict) insertKey(k Key, value interface{}) {
 	d.keys = append(d.keys, k)
 	d.values[k.ID] = value
 	atomic.AddInt64(&d.size, 1)
 	atomic.AddInt64(&d.version, 1)
-
-	return d
 }
 
 // Get retrieves an item from dict by key. If alt value is passed, it will be used as
-// default value if no item is found.
+// default value if no item is found. If d was created with NewDefault and alt is not
+// passed, a missing item is added with the value of the factory.
 // Returns a value matching key in dict, otherwise nil or alt if given.
 func (d *Dict) Get(key interface{}, alt ...interface{}) interface{} {
-	if d.IsEmpty() {
-		return nil
+	if d != nil && d.factory != nil && alt == nil {
+		return d.getDefault(key)
 	}
-
-	h, ok := d.GetKeyID(key)
-	if ok {
-		d.mu.RLock()
-		defer d.mu.RUnlock()
-		return d.values[h]
+	if value, ok := d.lookup(key); ok {
+		return value
 	}
 	if alt != nil {
 		return alt[0]
@@ -94,6 +177,25 @@ func (d *Dict) Get(key interface{}, alt ...interface{}) interface{} {
 	return nil
 }
 
+// lookup retrieves an item from dict by key, without adding the default value of dicts
+// made with NewDefault. Used by methods that only read d.
+// Returns the value and true, or nil and false if not found.
+func (d *Dict) lookup(key interface{}) (interface{}, bool) {
+	if d.IsEmpty() {
+		return nil, false
+	}
+
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+
+	id, ok := d.keyID(key)
+	if !ok {
+		return nil, false
+	}
+	value, ok := d.values[id]
+	return value, ok
+}
+
 // GetKeyID retrieves the ID of an item in dict, if found.
 // Returns the item ID and true, or 0 and false if not found.
 func (d *Dict) GetKeyID(key interface{}) (uint64, bool) {
@@ -101,16 +203,16 @@ func (d *Dict) GetKeyID(key interface{}) (uint64, bool) {
 		return 0, false
 	}
 
-	k := MakeKey(key)
-	if k == nil {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+
+	id, ok := d.keyID(key)
+	if !ok {
 		return 0, false
 	}
+	_, ok = d.values[id]
 
-	d.mu.RLock()
-	_, ok := d.values[k.ID]
-	d.mu.RUnlock()
-
-	return k.ID, ok
+	return id, ok
 }
 
 func (d *Dict) deleteItem(idx int) {
@@ -121,7 +223,7 @@ func (d *Dict) deleteItem(idx int) {
 	delete(d.values, d.keys[idx].ID)
 	copy(d.keys[idx:], d.keys[idx+1:])
 	l := len(d.keys)
-	d.keys[l-1] = nil
+	d.keys[l-1] = Key{}
 	d.keys = d.keys[:l-1]
 	atomic.StoreInt64(&d.size, int64(l-1))
 	atomic.AddInt64(&d.version, 1)
@@ -130,39 +232,60 @@ func (d *Dict) deleteItem(idx int) {
 // Del removes an item from dict by key name.
 // Returns true if an item is found and removed, false otherwise.
 func (d *Dict) Del(key interface{}) bool {
-	id, ok := d.GetKeyID(key)
-	if !ok {
+	if d.IsEmpty() {
 		return false
 	}
 
 	d.mu.Lock()
 	defer d.mu.Unlock()
 
-	var idx int
-	for i := range d.keys {
-		if d.keys[i].ID == id {
-			idx = i
-			break
-		}
+	id, ok := d.keyID(key)
+	if !ok {
+		return false
 	}
+	_, ok = d.deleteKey(id)
+	return ok
+}
 
-	if idx >= len(d.keys) || d.keys[idx].ID != id {
-		return false
+// popKey removes the item of key. The caller must hold the lock.
+// Returns the value of the item and true if found, otherwise nil and false.
+func (d *Dict) popKey(key interface{}) (interface{}, bool) {
+	id, ok := d.keyID(key)
+	if !ok {
+		return nil, false
 	}
+	return d.deleteKey(id)
+}
 
+// deleteKey removes the item with key ID id. The caller must hold the lock.
+// Returns the value of the item and true if found, otherwise nil and false.
+func (d *Dict) deleteKey(id uint64) (interface{}, bool) {
+	value, ok := d.values[id]
+	idx := d.indexOf(id)
+	if !ok || idx < 0 {
+		return nil, false
+	}
 	d.deleteItem(idx)
-
-	return true
+	return value, true
 }
 
-// Pop gets the value of a key and removes the item from the dict.
+// Pop gets the value of a key and removes the item from the dict, as one atomic
+// operation. Only one of many concurrent calls can pop the same item.
 // If the item is not found it returns alt. Otherwise it will return the value or nil.
 func (d *Dict) Pop(key interface{}, alt ...interface{}) interface{} {
-	value := d.Get(key, alt)
-	if value != nil {
-		d.Del(key)
+	if !d.IsEmpty() {
+		d.mu.Lock()
+		value, ok := d.popKey(key)
+		d.mu.Unlock()
+
+		if ok {
+			return value
+		}
+	}
+	if alt != nil {
+		return alt[0]
 	}
-	return value
+	return nil
 }
 
 // PopItem removes the most recent item added to the dict and returns it. If the dict is
@@ -214,7 +337,7 @@ func (d *Dict) Clear() bool {
 	atomic.StoreInt64(&d.size, 0)
 	atomic.AddInt64(&d.version, 1)
 
-	d.keys = []*Key{}
+	d.keys = []Key{}
 	d.values = make(map[uint64]interface{})
 	return true
 }
@@ -283,29 +406,47 @@ func (d *Dict) Items() <-chan Item {
 	return ci
 }
 
+// itemsSource is implemented by the dict types, so they can be used to update each other.
+type itemsSource interface {
+	Items() <-chan Item
+}
+
 // Update adds to d the key-value items from iterables, scalars and other dicts. Also replacing
-// any existing values that match the keys. This func is used by New() when initializing a
-// dict with values.
+// any existing values that match the keys. All the items are applied as one atomic batch,
+// so other goroutines see either none or all of the changes. This func is used by New()
+// when initializing a dict with values.
 // Returns true if any changes were made.
 func (d *Dict) Update(vargs ...interface{}) bool {
 	if vargs == nil {
 		return false
 	}
-	ver := d.Version()
+
+	// Read all items before locking, in case one of the dicts is d.
+	var items []Item
 	for i := range vargs {
 		// other dict
-		if other, ok := vargs[i].(*Dict); ok {
+		if other, ok := vargs[i].(itemsSource); ok {
 			for item := range other.Items() {
-				d.Set(item.Key, item.Value)
+				items = append(items, item)
 			}
 			continue
 		}
 		// iterables and scalars
 		for item := range toIterable(vargs[i]) {
-			if item.Key == nil {
-				item.Key = d.Len()
-			}
-			d.Set(item.Key, item.Value)
+			items = append(items, item)
+		}
+	}
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	ver := d.Version()
+	for _, item := range items {
+		if item.Key == nil {
+			item.Key = len(d.keys)
+		}
+		if k, ok := d.makeKey(item.Key); ok {
+			d.setKey(k, item.Value)
 		}
 	}
 	return ver != d.Version()
@@ -42,6 +42,8 @@ func TestDict(t *testing.T) {
 		{"string", testPrint},
 		{"update", testUpdate},
 		{"workers", testWorkers},
+		{"workers pop", testWorkersPop},
+		{"workers update", testWorkersUpdate},
 	}
 	for _, tc := range tests {
 		if !t.Run(tc.name, wrapFn(tc.fn)) {
@@ -499,6 +501,75 @@ func testWorkers(t *testing.T, d *Dict) {
 	require.Equal(t, v, "value99")
 }
 
+func testWorkersPop(t *testing.T, d *Dict) {
+	var wg sync.WaitGroup
+
+	d.Clear()
+
+	numWorkers := 7
+	numItems := 100
+
+	for i := 0; i < numItems; i++ {
+		d.Set(i, i)
+	}
+
+	var popped int64
+	wg.Add(numWorkers)
+	for i := 0; i < numWorkers; i++ {
+		go func() {
+			defer wg.Done()
+			for j := 0; j < numItems; j++ {
+				if d.Pop(j) != nil {
+					atomic.AddInt64(&popped, 1)
+				}
+			}
+		}()
+	}
+	wg.Wait()
+
+	require.EqualValues(t, numItems, popped, "every item must be popped exactly once")
+	require.True(t, d.IsEmpty())
+	require.Equal(t, "alt", d.Pop(0, "alt"))
+}
+
+func testWorkersUpdate(t *testing.T, d *Dict) {
+	var wg sync.WaitGroup
+
+	d.Clear()
+
+	numWorkers := 7
+	numItems := 100
+
+	var torn int64
+	wg.Add(numWorkers * 2)
+	for i := 0; i < numWorkers; i++ {
+		go func(i int) {
+			defer wg.Done()
+			for j := 0; j < numItems; j++ {
+				v := i*numItems + j
+				d.Update(New().Set("a", v).Set("b", v))
+			}
+		}(i)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < numItems; j++ {
+				// Items are a snapshot, so both values come from the same batch.
+				var values []interface{}
+				for item := range d.Items() {
+					values = append(values, item.Value)
+				}
+				if len(values) == 2 && values[0] != values[1] {
+					atomic.AddInt64(&torn, 1)
+				}
+			}
+		}()
+	}
+	wg.Wait()
+
+	require.Zero(t, torn, "readers must not see a partial update")
+	require.Equal(t, d.Get("a"), d.Get("b"))
+}
+
 func TestPopDoesNotDeadlock(t *testing.T) {
 	d := New()
 	d.Set("deadlock", "sentinel")
@@ -619,3 +690,41 @@ func TestPopItemDoesNotPanicWithStaleSize(t *testing.T) {
 		require.Nil(t, d.PopItem())
 	})
 }
+
+func TestCapacity(t *testing.T) {
+	d := NewWithCapacity(10)
+	require.True(t, d.IsEmpty())
+	require.Equal(t, 10, cap(d.keys))
+	require.Equal(t, 0, cap(NewWithCapacity(-1).keys))
+
+	for i := 0; i < 10; i++ {
+		d.Set(i, i)
+	}
+	require.Equal(t, 10, cap(d.keys))
+
+	ver := d.Version()
+	d.Grow(90)
+	require.True(t, cap(d.keys) >= 100)
+	require.Equal(t, 10, d.Len())
+	require.Equal(t, ver, d.Version())
+	require.Equal(t, 9, d.Get(9))
+
+	// Growing with enough room is a no-op, otherwise the capacity at least doubles.
+	c := cap(d.keys)
+	d.Grow(c - d.Len())
+	require.Equal(t, c, cap(d.keys))
+	d.Grow(c - d.Len() + 1)
+	require.Equal(t, 2*c, cap(d.keys))
+
+	d.DelMany(1, 2, 3, 4, 5, 6, 7, 8)
+	d.Compact()
+	require.Equal(t, 2, cap(d.keys))
+	require.Equal(t, []string{"0", "9"}, d.Keys())
+	require.Equal(t, 9, d.Get(9))
+
+	var nd *Dict
+	require.NotPanics(t, func() {
+		nd.Grow(1)
+		nd.Compact()
+	})
+}
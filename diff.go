@@ -0,0 +1,166 @@
+// Copyright (c) 2025 srfrog - https://srfrog.dev
+// Use of this source code is governed by the license in the LICENSE file.
+
+package dict
+
+import (
+	"fmt"
+	"strings"
+)
+
+// ChangeKind is the type of change found by Diff.
+type ChangeKind int
+
+// Kinds of changes.
+const (
+	ChangeAdded ChangeKind = iota + 1
+	ChangeRemoved
+	ChangeModified
+	ChangeReordered
+)
+
+func (k ChangeKind) String() string {
+	switch k {
+	case ChangeAdded:
+		return "added"
+	case ChangeRemoved:
+		return "removed"
+	case ChangeModified:
+		return "modified"
+	case ChangeReordered:
+		return "reordered"
+	}
+	return "unknown"
+}
+
+// Change is a single difference between two dicts.
+// Kind is the type of change.
+// Path is the JSON Pointer (RFC 6901) to the item, or to the dict that was reordered.
+// Old and New are the values before and after the change. Old is nil for added items and
+// New is nil for removed items. For reordered dicts, they are the []string of the keys
+// common to both dicts in their old and new order.
+type Change struct {
+	Kind     ChangeKind
+	Path     string
+	Old, New interface{}
+}
+
+// String returns the change in a single line, prefixed with the unified diff markers
+// "-" (removed), "+" (added) and "~" (reordered). A modified item uses both markers, one
+// per line.
+func (c Change) String() string {
+	switch c.Kind {
+	case ChangeAdded:
+		return fmt.Sprintf("+ %s: %s", c.Path, formatValue(c.New))
+	case ChangeRemoved:
+		return fmt.Sprintf("- %s: %s", c.Path, formatValue(c.Old))
+	case ChangeModified:
+		return fmt.Sprintf("- %s: %s\n+ %s: %s", c.Path, formatValue(c.Old), c.Path, formatValue(c.New))
+	case ChangeReordered:
+		return fmt.Sprintf("~ %s: %v -> %v", c.Path, c.Old, c.New)
+	}
+	return ""
+}
+
+// Diff compares dict a to dict b, recursing into the embedded dicts found in both.
+// Changes for each dict are reported as: reordering of the keys found in both, then removed
+// and modified items in the order of a, then added items in the order of b.
+// Returns the list of changes, or nil if the dicts are equal.
+func Diff(a, b *Dict) []Change {
+	return diffDict(nil, "", a, b)
+}
+
+// FormatDiff renders changes as a unified-style diff, one line per change, using the
+// labels of the old and new dicts in the header.
+// Returns the formatted diff, or an empty string if there are no changes.
+func FormatDiff(changes []Change, oldLabel, newLabel string) string {
+	if len(changes) == 0 {
+		return ""
+	}
+
+	var sb strings.Builder
+	sb.WriteString("--- " + oldLabel + "\n")
+	sb.WriteString("+++ " + newLabel + "\n")
+	for i := range changes {
+		sb.WriteString(changes[i].String())
+		sb.WriteByte('\n')
+	}
+	return sb.String()
+}
+
+func diffDict(changes []Change, path string, a, b *Dict) []Change {
+	// Compare snapshots, so the dicts can be changed by other goroutines meanwhile.
+	akeys, avalues := diffSnapshot(a)
+	bkeys, bvalues := diffSnapshot(b)
+
+	// Compare the order of the keys in both dicts.
+	var aorder, border []string
+	for _, key := range akeys {
+		if _, ok := bvalues[key]; ok {
+			aorder = append(aorder, key)
+		}
+	}
+	for _, key := range bkeys {
+		if _, ok := avalues[key]; ok {
+			border = append(border, key)
+		}
+	}
+	for i := range aorder {
+		if aorder[i] != border[i] {
+			changes = append(changes, Change{Kind: ChangeReordered, Path: path, Old: aorder, New: border})
+			break
+		}
+	}
+
+	for _, key := range akeys {
+		p := path + "/" + escapePointer(key)
+		av := avalues[key]
+		bv, ok := bvalues[key]
+		if !ok {
+			changes = append(changes, Change{Kind: ChangeRemoved, Path: p, Old: av})
+			continue
+		}
+		ad, aok := av.(*Dict)
+		bd, bok := bv.(*Dict)
+		if aok && bok {
+			changes = diffDict(changes, p, ad, bd)
+			continue
+		}
+		if !valuesEqual(av, bv) {
+			changes = append(changes, Change{Kind: ChangeModified, Path: p, Old: av, New: bv})
+		}
+	}
+
+	for _, key := range bkeys {
+		if _, ok := avalues[key]; !ok {
+			changes = append(changes, Change{Kind: ChangeAdded, Path: path + "/" + escapePointer(key), New: bvalues[key]})
+		}
+	}
+	return changes
+}
+
+// diffSnapshot returns the key names of d, in order, and its values by key name, read
+// under one lock.
+func diffSnapshot(d *Dict) ([]string, map[string]interface{}) {
+	if d.IsEmpty() {
+		return nil, nil
+	}
+
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+
+	keys := make([]string, len(d.keys))
+	values := make(map[string]interface{}, len(d.keys))
+	for i, key := range d.keys {
+		keys[i] = key.Name
+		values[key.Name] = d.values[key.ID]
+	}
+	return keys, values
+}
+
+func formatValue(v interface{}) string {
+	if d, ok := v.(*Dict); ok {
+		return d.String()
+	}
+	return fmt.Sprintf("%#v", v)
+}
@@ -0,0 +1,84 @@
+// Copyright (c) 2025 srfrog - https://srfrog.dev
+// Use of this source code is governed by the license in the LICENSE file.
+
+package dict
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestDiff(t *testing.T) {
+	a := New().
+		Set("name", "gopher").
+		Set("port", 8080).
+		Set("owner", New().Set("id", 7).Set("email", "gopher@example.com")).
+		Set("tags", []string{"go"})
+	b := New().
+		Set("port", float64(8080)).
+		Set("owner", New().Set("email", "gopher@example.com").Set("id", 8)).
+		Set("tags", []string{"go", "dict"}).
+		Set("debug", true)
+
+	changes := Diff(a, b)
+	require.Equal(t, []Change{
+		{Kind: ChangeRemoved, Path: "/name", Old: "gopher"},
+		{Kind: ChangeReordered, Path: "/owner", Old: []string{"id", "email"}, New: []string{"email", "id"}},
+		{Kind: ChangeModified, Path: "/owner/id", Old: 7, New: 8},
+		{Kind: ChangeModified, Path: "/tags", Old: []string{"go"}, New: []string{"go", "dict"}},
+		{Kind: ChangeAdded, Path: "/debug", New: true},
+	}, changes)
+
+	out := FormatDiff(changes, "a", "b")
+	require.Equal(t, `--- a
++++ b
+- /name: "gopher"
+~ /owner: [id email] -> [email id]
+- /owner/id: 7
++ /owner/id: 8
+- /tags: []string{"go"}
++ /tags: []string{"go", "dict"}
++ /debug: true
+`, out)
+}
+
+func TestDiffReordered(t *testing.T) {
+	a := New(1, 2, 3)
+	b := New().Set(2, 3).Set(0, 1).Set(1, 2)
+
+	require.Equal(t, []Change{
+		{Kind: ChangeReordered, Path: "", Old: []string{"0", "1", "2"}, New: []string{"2", "0", "1"}},
+	}, Diff(a, b))
+}
+
+func TestDiffEqual(t *testing.T) {
+	a := New().Set("x", New().Set("y", []int{1, 2}))
+	b := New().Set("x", New().Set("y", []int{1, 2}))
+
+	require.Nil(t, Diff(a, b))
+	require.Nil(t, Diff(New(), nil))
+	require.Empty(t, FormatDiff(Diff(a, b), "a", "b"))
+}
+
+func TestDiffConcurrent(t *testing.T) {
+	a := New(map[int]int{0: 0, 1: 1, 2: 2, 3: 3})
+	b := New(map[int]int{3: 3, 2: 2, 1: 1, 0: 0})
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for i := 0; i < 1000; i++ {
+			b.Del(i % 4)
+			a.Del((i + 1) % 4)
+			b.Set(i%4, i)
+			a.Set((i+1)%4, i)
+		}
+	}()
+	require.NotPanics(t, func() {
+		for i := 0; i < 1000; i++ {
+			Diff(a, b)
+		}
+	})
+	<-done
+}
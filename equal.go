@@ -0,0 +1,129 @@
+// Copyright (c) 2025 srfrog - https://srfrog.dev
+// Use of this source code is governed by the license in the LICENSE file.
+
+package dict
+
+import (
+	"reflect"
+	"strconv"
+)
+
+// EqualFunc is a function that compares two dict values for equality. It's called for
+// each pair of values that are not embedded dicts or slices, which are always compared
+// item by item.
+type EqualFunc func(a, b interface{}) bool
+
+// Equal compares dict d to other using Python dict semantics: both dicts have the same keys
+// with equal values, regardless of the order of the keys. Embedded dicts are compared
+// recursively. Numbers are equal if they have the same value, e.g., int(1) and
+// float64(1), otherwise values are compared with reflect.DeepEqual. If eq is passed, it's
+// used instead to compare values, e.g., to compare floats with a tolerance.
+// Returns true if the dicts are equal, false otherwise.
+func (d *Dict) Equal(other *Dict, eq ...EqualFunc) bool {
+	return equalDict(d, other, false, firstEqualFunc(eq))
+}
+
+// EqualOrdered is like Equal but the keys must also be in the same order, like Python
+// OrderedDict. Embedded dicts are also compared in order.
+// Returns true if the dicts are equal, false otherwise.
+func (d *Dict) EqualOrdered(other *Dict, eq ...EqualFunc) bool {
+	return equalDict(d, other, true, firstEqualFunc(eq))
+}
+
+func firstEqualFunc(eq []EqualFunc) EqualFunc {
+	if eq != nil {
+		return eq[0]
+	}
+	return nil
+}
+
+func equalDict(a, b *Dict, ordered bool, eq EqualFunc) bool {
+	if a == b {
+		return true
+	}
+	if a.IsEmpty() || b.IsEmpty() {
+		return a.IsEmpty() && b.IsEmpty()
+	}
+
+	items := make([]Item, 0, a.Len())
+	for item := range a.Items() {
+		items = append(items, item)
+	}
+	if len(items) != b.Len() {
+		return false
+	}
+
+	var keys []string
+	if ordered {
+		if keys = b.Keys(); len(keys) != len(items) {
+			return false
+		}
+	}
+	for i := range items {
+		if ordered && keys[i] != items[i].Key {
+			return false
+		}
+		value, ok := b.lookup(items[i].Key)
+		if !ok || !equalValues(items[i].Value, value, ordered, eq) {
+			return false
+		}
+	}
+	return true
+}
+
+// valuesEqual compares two dict values with the defaults used by Equal.
+func valuesEqual(a, b interface{}) bool {
+	return equalValues(a, b, false, nil)
+}
+
+func equalValues(a, b interface{}, ordered bool, eq EqualFunc) bool {
+	ad, aok := a.(*Dict)
+	bd, bok := b.(*Dict)
+	if aok || bok {
+		return aok && bok && equalDict(ad, bd, ordered, eq)
+	}
+
+	av, bv := reflect.ValueOf(a), reflect.ValueOf(b)
+	if isList(av) && isList(bv) {
+		if av.Len() != bv.Len() {
+			return false
+		}
+		for i := 0; i < av.Len(); i++ {
+			if !equalValues(av.Index(i).Interface(), bv.Index(i).Interface(), ordered, eq) {
+				return false
+			}
+		}
+		return true
+	}
+
+	if eq != nil {
+		return eq(a, b)
+	}
+	if isNumber(av) && isNumber(bv) {
+		return numberString(av) == numberString(bv)
+	}
+	return reflect.DeepEqual(a, b)
+}
+
+func isList(v reflect.Value) bool {
+	return v.IsValid() && (v.Kind() == reflect.Slice || v.Kind() == reflect.Array)
+}
+
+func isNumber(v reflect.Value) bool {
+	if !v.IsValid() || v.Type().Implements(stringerType) {
+		return false
+	}
+	kind := v.Kind()
+	return (kind >= reflect.Int && kind <= reflect.Uint64) ||
+		kind == reflect.Float32 || kind == reflect.Float64
+}
+
+func numberString(v reflect.Value) string {
+	switch kind := v.Kind(); {
+	case kind >= reflect.Int && kind <= reflect.Int64:
+		return strconv.FormatInt(v.Int(), 10)
+	case kind >= reflect.Uint && kind <= reflect.Uint64:
+		return strconv.FormatUint(v.Uint(), 10)
+	}
+	return strconv.FormatFloat(v.Float(), 'f', -1, 64)
+}
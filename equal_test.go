@@ -0,0 +1,60 @@
+// Copyright (c) 2025 srfrog - https://srfrog.dev
+// Use of this source code is governed by the license in the LICENSE file.
+
+package dict
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestEqual(t *testing.T) {
+	tests := []struct {
+		name      string
+		a, b      *Dict
+		eq, eqOrd bool
+	}{
+		{"nil", nil, nil, true, true},
+		{"nil and empty", nil, New(), true, true},
+		{"empty and non-empty", New(), New(1), false, false},
+		{"same", New(1, 2, 3), New(1, 2, 3), true, true},
+		{"numbers", New(1, 2, 3), New(1.0, uint8(2), int64(3)), true, true},
+		{"order", New().Set("a", 1).Set("b", 2), New().Set("b", 2).Set("a", 1), true, false},
+		{"value", New().Set("a", 1), New().Set("a", 2), false, false},
+		{"key", New().Set("a", 1), New().Set("b", 1), false, false},
+		{"len", New(1, 2), New(1, 2, 3), false, false},
+		{"slices", New([]interface{}{[]int{1, 2}}), New([]interface{}{[]float64{1, 2}}), true, true},
+		{"embed", New().Set("x", New().Set("a", 1).Set("b", 2)), New().Set("x", New().Set("b", 2).Set("a", 1)), true, false},
+		{"embed value", New().Set("x", New().Set("a", 1)), New().Set("x", New().Set("a", "1")), false, false},
+		{"embed and scalar", New().Set("x", New()), New().Set("x", 1), false, false},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			require.Equal(t, tc.eq, tc.a.Equal(tc.b))
+			require.Equal(t, tc.eq, tc.b.Equal(tc.a))
+			require.Equal(t, tc.eqOrd, tc.a.EqualOrdered(tc.b))
+			require.Equal(t, tc.eqOrd, tc.b.EqualOrdered(tc.a))
+		})
+	}
+}
+
+func TestEqualFunc(t *testing.T) {
+	approx := func(a, b interface{}) bool {
+		af, aok := a.(float64)
+		bf, bok := b.(float64)
+		if aok && bok {
+			return math.Abs(af-bf) < 1e-9
+		}
+		return a == b
+	}
+
+	x, y := 0.1, 0.2
+	a := New().Set("x", x+y).Set("y", New().Set("z", []float64{0.3}))
+	b := New().Set("x", 0.3).Set("y", New().Set("z", []float64{x + y}))
+
+	require.False(t, a.Equal(b))
+	require.True(t, a.Equal(b, approx))
+	require.True(t, a.EqualOrdered(b, approx))
+}
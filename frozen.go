@@ -0,0 +1,356 @@
+// Copyright (c) 2025 srfrog - https://srfrog.dev
+// Use of this source code is governed by the license in the LICENSE file.
+
+package dict
+
+import (
+	"fmt"
+	"hash/fnv"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// FrozenDict is an immutable dict. It has no methods to change its items, so it can be
+// shared by goroutines without locking. Embedded dicts, also the ones in slices, arrays and
+// maps, are frozen too.
+//
+// A FrozenDict implements Stringer with a canonical form, with items sorted by key, so it
+// can be used as a key in other dicts; frozen dicts with equal items make the same key.
+// Use Dict.Freeze to create a FrozenDict.
+type FrozenDict struct {
+	keys   []Key
+	values map[uint64]interface{}
+	str    string
+	hash   uint64
+	hasher Hasher
+}
+
+// Freeze returns an immutable copy of d. Embedded dicts, also the ones in slices, arrays and
+// maps, are frozen recursively, and the other values are deep copied as in DeepCopy.
+func (d *Dict) Freeze() *FrozenDict {
+	if d.IsEmpty() {
+		return freeze(nil, nil, nil)
+	}
+
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+
+	return freeze(d.keys, d.values, d.hasher)
+}
+
+// freeze returns a FrozenDict with copies of keys and values, which are not changed. The
+// key IDs were made with hasher, or FNV if nil.
+func freeze(keys []Key, values map[uint64]interface{}, hasher Hasher) *FrozenDict {
+	f := &FrozenDict{
+		hasher: hasher,
+		keys:   append([]Key(nil), keys...),
+		values: make(map[uint64]interface{}, len(values)),
+	}
+	for id, v := range values {
+		f.values[id] = convertDicts(deepCopyValue(v), dictType, frozenDictType, func(v interface{}) interface{} {
+			return v.(*Dict).Freeze()
+		})
+	}
+
+	// Canonical string and hash, sorted by key name.
+	sorted := append([]Key(nil), f.keys...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].Name < sorted[j].Name })
+	items := make([]string, len(sorted))
+	for i, key := range sorted {
+		items[i] = fmt.Sprintf("%q: %s", key.Name, canonicalString(reflect.ValueOf(f.values[key.ID])))
+	}
+	f.str = "{" + strings.Join(items, ", ") + "}"
+
+	h := fnv.New64a()
+	_, _ = h.Write([]byte(f.str))
+	f.hash = h.Sum64()
+
+	return f
+}
+
+var (
+	dictType       = reflect.TypeOf((*Dict)(nil))
+	frozenDictType = reflect.TypeOf((*FrozenDict)(nil))
+)
+
+// convertDicts returns v with the values of type from, including the ones in slices,
+// arrays and maps, replaced by fn. The containers that hold them are copied, with their
+// element type changed to to.
+func convertDicts(v interface{}, from, to reflect.Type, fn func(v interface{}) interface{}) interface{} {
+	rv := reflect.ValueOf(v)
+	if !rv.IsValid() || !holdsType(rv.Type(), from) {
+		return v
+	}
+	return convertReflect(rv, from, to, fn).Interface()
+}
+
+func convertReflect(v reflect.Value, from, to reflect.Type, fn func(v interface{}) interface{}) reflect.Value {
+	t := v.Type()
+	if !holdsType(t, from) {
+		return v
+	}
+
+	switch t.Kind() {
+	case reflect.Interface:
+		if v.IsNil() {
+			return v
+		}
+		return convertReflect(v.Elem(), from, to, fn)
+
+	case reflect.Slice:
+		if v.IsNil() {
+			return reflect.Zero(convertType(t, from, to))
+		}
+		c := reflect.MakeSlice(convertType(t, from, to), v.Len(), v.Len())
+		for i := 0; i < v.Len(); i++ {
+			c.Index(i).Set(convertReflect(v.Index(i), from, to, fn))
+		}
+		return c
+
+	case reflect.Array:
+		c := reflect.New(convertType(t, from, to)).Elem()
+		for i := 0; i < v.Len(); i++ {
+			c.Index(i).Set(convertReflect(v.Index(i), from, to, fn))
+		}
+		return c
+
+	case reflect.Map:
+		if v.IsNil() {
+			return reflect.Zero(convertType(t, from, to))
+		}
+		c := reflect.MakeMapWithSize(convertType(t, from, to), v.Len())
+		for iter := v.MapRange(); iter.Next(); {
+			c.SetMapIndex(iter.Key(), convertReflect(iter.Value(), from, to, fn))
+		}
+		return c
+	}
+
+	// t is from.
+	if v.IsNil() {
+		return reflect.Zero(to)
+	}
+	return reflect.ValueOf(fn(v.Interface()))
+}
+
+// holdsType returns true if values of type t can be or hold values of type from.
+func holdsType(t, from reflect.Type) bool {
+	switch t.Kind() {
+	case reflect.Interface:
+		return true
+	case reflect.Slice, reflect.Array, reflect.Map:
+		return holdsType(t.Elem(), from)
+	}
+	return t == from
+}
+
+// convertType returns t with the type from replaced by to, as done by convertDicts.
+func convertType(t, from, to reflect.Type) reflect.Type {
+	switch t.Kind() {
+	case reflect.Slice:
+		return reflect.SliceOf(convertType(t.Elem(), from, to))
+	case reflect.Array:
+		return reflect.ArrayOf(t.Len(), convertType(t.Elem(), from, to))
+	case reflect.Map:
+		return reflect.MapOf(t.Key(), convertType(t.Elem(), from, to))
+	}
+	if t == from {
+		return to
+	}
+	return t
+}
+
+// canonicalString returns the Go syntax representation of v, as with the %#v verb, but
+// with map entries sorted and without pointer addresses, so equal values always have the
+// same string. Frozen dicts are represented by their String.
+func canonicalString(v reflect.Value) string {
+	if !v.IsValid() {
+		return "nil"
+	}
+
+	t := v.Type()
+	if t == frozenDictType {
+		if v.IsNil() {
+			return "(*dict.FrozenDict)(nil)"
+		}
+		return v.Interface().(*FrozenDict).String()
+	}
+
+	switch t.Kind() {
+	case reflect.Interface:
+		if v.IsNil() {
+			return "nil"
+		}
+		return canonicalString(v.Elem())
+
+	case reflect.Ptr:
+		if v.IsNil() {
+			return "(" + t.String() + ")(nil)"
+		}
+		return "&" + canonicalString(v.Elem())
+
+	case reflect.Slice, reflect.Array:
+		if t.Kind() == reflect.Slice && v.IsNil() {
+			return t.String() + "(nil)"
+		}
+		items := make([]string, v.Len())
+		for i := range items {
+			items[i] = canonicalString(v.Index(i))
+		}
+		return t.String() + "{" + strings.Join(items, ", ") + "}"
+
+	case reflect.Map:
+		if v.IsNil() {
+			return t.String() + "(nil)"
+		}
+		items := make([]string, 0, v.Len())
+		for iter := v.MapRange(); iter.Next(); {
+			items = append(items, canonicalString(iter.Key())+": "+canonicalString(iter.Value()))
+		}
+		sort.Strings(items)
+		return t.String() + "{" + strings.Join(items, ", ") + "}"
+
+	case reflect.Struct:
+		items := make([]string, v.NumField())
+		for i := range items {
+			items[i] = t.Field(i).Name + ":" + canonicalString(v.Field(i))
+		}
+		return t.String() + "{" + strings.Join(items, ", ") + "}"
+
+	case reflect.Chan, reflect.Func, reflect.UnsafePointer:
+		// Only the type, the address is not stable.
+		return t.String()
+	}
+
+	return fmt.Sprintf("%#v", v)
+}
+
+// keyID returns the ID of the Key made from value using the Hasher of the dict f was
+// made from.
+func (f *FrozenDict) keyID(value interface{}) (uint64, bool) {
+	if f == nil {
+		return hashKey(value, nil)
+	}
+	return hashKey(value, f.hasher)
+}
+
+// Len returns the size of a FrozenDict.
+func (f *FrozenDict) Len() int {
+	if f == nil {
+		return 0
+	}
+	return len(f.keys)
+}
+
+// IsEmpty returns true if the dict is empty, false otherwise.
+func (f *FrozenDict) IsEmpty() bool {
+	return f.Len() == 0
+}
+
+// Get retrieves an item from dict by key. If alt value is passed, it will be used as
+// default value if no item is found.
+// Returns a value matching key in dict, otherwise nil or alt if given.
+func (f *FrozenDict) Get(key interface{}, alt ...interface{}) interface{} {
+	if id, ok := f.keyID(key); ok && !f.IsEmpty() {
+		if v, ok := f.values[id]; ok {
+			return v
+		}
+	}
+	if alt != nil {
+		return alt[0]
+	}
+	return nil
+}
+
+// Key returns true if key is in dict f, false otherwise.
+func (f *FrozenDict) Key(key interface{}) bool {
+	id, ok := f.keyID(key)
+	if !ok || f.IsEmpty() {
+		return false
+	}
+	_, ok = f.values[id]
+	return ok
+}
+
+// Keys returns a string slice of all dict keys, or nil if dict is empty.
+func (f *FrozenDict) Keys() []string {
+	if f.IsEmpty() {
+		return nil
+	}
+	keys := make([]string, len(f.keys))
+	for i := range f.keys {
+		keys[i] = f.keys[i].Name
+	}
+	return keys
+}
+
+// Values returns a slice of all dict values, or nil if dict is empty.
+func (f *FrozenDict) Values() []interface{} {
+	if f.IsEmpty() {
+		return nil
+	}
+	values := make([]interface{}, len(f.keys))
+	for i, key := range f.keys {
+		values[i] = f.values[key.ID]
+	}
+	return values
+}
+
+// Items returns a channel of key-value items.
+func (f *FrozenDict) Items() <-chan Item {
+	ci := make(chan Item)
+	go func() {
+		defer close(ci)
+		for i := 0; i < f.Len(); i++ {
+			ci <- Item{Key: f.keys[i].Name, Value: f.values[f.keys[i].ID]}
+		}
+	}()
+	return ci
+}
+
+// Hash returns the hash of the content of f, which is the same for frozen dicts with
+// equal items regardless of their order. It's the ID of the Key made from f.
+func (f *FrozenDict) Hash() uint64 {
+	if f == nil {
+		return freeze(nil, nil, nil).hash
+	}
+	return f.hash
+}
+
+// Equal returns true if f and other have the same items, regardless of order.
+func (f *FrozenDict) Equal(other *FrozenDict) bool {
+	return f.String() == other.String()
+}
+
+// Dict returns a new mutable dict with the items of f. Embedded frozen dicts are also
+// converted to dicts.
+func (f *FrozenDict) Dict() *Dict {
+	d := New()
+	if f != nil {
+		d.hasher = f.hasher
+	}
+	for item := range f.Items() {
+		d.Set(item.Key, convertDicts(item.Value, frozenDictType, dictType, func(v interface{}) interface{} {
+			return v.(*FrozenDict).Dict()
+		}))
+	}
+	return d
+}
+
+// String implements the fmt.Stringer interface to print f similar to a Python dict. The
+// items are sorted by key, so equal frozen dicts have the same string.
+func (f *FrozenDict) String() string {
+	if f == nil {
+		return "{}"
+	}
+	return f.str
+}
+
+// MarshalJSON implements the json.MarshalJSON interface.
+// The JSON representation of a frozen dict is just a JSON object.
+func (f *FrozenDict) MarshalJSON() ([]byte, error) {
+	if f.IsEmpty() {
+		return []byte("null"), nil
+	}
+	return marshalItems(f.Items())
+}
@@ -0,0 +1,129 @@
+// Copyright (c) 2025 srfrog - https://srfrog.dev
+// Use of this source code is governed by the license in the LICENSE file.
+
+package dict
+
+import (
+	"encoding/json"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestFreeze(t *testing.T) {
+	d := New().Set("b", 2).Set("a", []interface{}{1, 2}).Set("c", New().Set("x", 1))
+	f := d.Freeze()
+
+	require.Equal(t, 3, f.Len())
+	require.Equal(t, []string{"b", "a", "c"}, f.Keys())
+	require.Equal(t, 2, f.Get("b"))
+	require.Equal(t, "alt", f.Get("missing", "alt"))
+	require.True(t, f.Key("a"))
+	require.False(t, f.Key("missing"))
+	require.IsType(t, &FrozenDict{}, f.Get("c"))
+	require.Equal(t, `{"a": []interface {}{1, 2}, "b": 2, "c": {"x": 1}}`, f.String())
+
+	// Changes to the dict don't affect the frozen copy.
+	d.Set("b", 3)
+	d.Get("a").([]interface{})[0] = 10
+	d.Get("c").(*Dict).Set("y", 2)
+	require.Equal(t, 2, f.Get("b"))
+	require.Equal(t, []interface{}{1, 2}, f.Get("a"))
+	require.Equal(t, 1, f.Get("c").(*FrozenDict).Len())
+
+	b, err := json.Marshal(f)
+	require.NoError(t, err)
+	require.JSONEq(t, `{"b": 2, "a": [1, 2], "c": {"x": 1}}`, string(b))
+
+	thawed := f.Dict()
+	require.Equal(t, []string{"b", "a", "c"}, thawed.Keys())
+	require.IsType(t, &Dict{}, thawed.Get("c"))
+
+	var n int
+	for range f.Items() {
+		n++
+	}
+	require.Equal(t, 3, n)
+}
+
+func TestFrozenDictHash(t *testing.T) {
+	f1 := New().Set("a", 1).Set("b", 2).Freeze()
+	f2 := New().Set("b", 2).Set("a", 1).Freeze()
+	f3 := New().Set("a", 1).Set("b", 3).Freeze()
+
+	require.True(t, f1.Equal(f2))
+	require.False(t, f1.Equal(f3))
+	require.Equal(t, f1.Hash(), f2.Hash())
+	require.NotEqual(t, f1.Hash(), f3.Hash())
+	require.Equal(t, MakeKey(f1).ID, f1.Hash())
+
+	// Frozen dicts with equal items make the same key.
+	d := New().Set(f1, "first")
+	require.True(t, d.Key(f2))
+	require.False(t, d.Key(f3))
+	d.Set(f2, "second")
+	require.Equal(t, 1, d.Len())
+	require.Equal(t, "second", d.Get(f1))
+
+	var nf *FrozenDict
+	require.True(t, nf.IsEmpty())
+	require.Nil(t, nf.Keys())
+	require.Equal(t, "{}", nf.String())
+	require.Equal(t, New().Freeze().Hash(), nf.Hash())
+}
+
+func TestFrozenDictCanonical(t *testing.T) {
+	// Key names are quoted, so they can't be confused with the separators.
+	f1 := New().Set("a: 1, b", 2).Freeze()
+	f2 := New().Set("a", 1).Set("b", 2).Freeze()
+	require.False(t, f1.Equal(f2))
+	require.NotEqual(t, f1.Hash(), f2.Hash())
+
+	// Dicts in slices and maps are frozen, and pointers are printed by value, so equal
+	// dicts have the same hash.
+	newDict := func() *Dict {
+		x := 1
+		return New().
+			Set("list", []interface{}{New().Set("x", 1), "y"}).
+			Set("dicts", []*Dict{New().Set("z", 2), nil}).
+			Set("map", map[string]interface{}{"b": New().Set("y", 2), "a": 1}).
+			Set("ptr", &x)
+	}
+	f1, f2 = newDict().Freeze(), newDict().Freeze()
+	require.True(t, f1.Equal(f2))
+	require.Equal(t, f1.Hash(), f2.Hash())
+	require.Equal(t, `{"dicts": []*dict.FrozenDict{{"z": 2}, (*dict.FrozenDict)(nil)}, `+
+		`"list": []interface {}{{"x": 1}, "y"}, `+
+		`"map": map[string]interface {}{"a": 1, "b": {"y": 2}}, "ptr": &1}`, f1.String())
+
+	list := f1.Get("list").([]interface{})
+	require.IsType(t, &FrozenDict{}, list[0])
+	require.IsType(t, []*FrozenDict{}, f1.Get("dicts"))
+	require.IsType(t, &FrozenDict{}, f1.Get("map").(map[string]interface{})["b"])
+
+	// Thawing converts them back to dicts.
+	d := f1.Dict()
+	require.IsType(t, &Dict{}, d.Get("list").([]interface{})[0])
+	require.IsType(t, []*Dict{}, d.Get("dicts"))
+	require.True(t, d.Equal(newDict()))
+}
+
+func TestFrozenDictConcurrent(t *testing.T) {
+	f := New(map[string]int{"a": 1, "b": 2, "c": 3}).Freeze()
+
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 100; j++ {
+				_ = f.Get("a")
+				_ = f.Keys()
+				_ = f.String()
+			}
+		}()
+	}
+	wg.Wait()
+	require.Equal(t, 1, f.Get("a"))
+}
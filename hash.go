@@ -0,0 +1,167 @@
+// Copyright (c) 2025 srfrog - https://srfrog.dev
+// Use of this source code is governed by the license in the LICENSE file.
+
+package dict
+
+import (
+	"encoding/binary"
+	"hash/maphash"
+	"math/bits"
+)
+
+// Hasher is a hash function for key names, used to make the Key.ID of the items in a dict.
+// It must always return the same value for the same input.
+type Hasher func(b []byte) uint64
+
+// FNV is the default Hasher of a dict, 64 bit FNV-1a, as used by MakeKey. It's fast but
+// its hashes can be predicted, so an attacker can craft keys that collide. Use a keyed
+// Hasher, like NewSipHasher or NewMapHasher, for keys from untrusted sources.
+func FNV(b []byte) uint64 {
+	return fnvBytes(b)
+}
+
+// NewMapHasher returns a Hasher that uses hash/maphash with a new random seed. The hashes
+// are different for every Hasher and process, so they must not be stored.
+func NewMapHasher() Hasher {
+	seed := maphash.MakeSeed()
+	return func(b []byte) uint64 {
+		var h maphash.Hash
+		h.SetSeed(seed)
+		_, _ = h.Write(b)
+		return h.Sum64()
+	}
+}
+
+// NewSipHasher returns a Hasher that uses SipHash-2-4 with the 128 bit key k0, k1. The key
+// must be secret and random to protect from crafted keys.
+func NewSipHasher(k0, k1 uint64) Hasher {
+	return func(b []byte) uint64 {
+		return sipHash(k0, k1, b)
+	}
+}
+
+// sipHash returns the SipHash-2-4 of p with key k0, k1.
+func sipHash(k0, k1 uint64, p []byte) uint64 {
+	v0 := k0 ^ 0x736f6d6570736575
+	v1 := k1 ^ 0x646f72616e646f6d
+	v2 := k0 ^ 0x6c7967656e657261
+	v3 := k1 ^ 0x7465646279746573
+
+	round := func() {
+		v0 += v1
+		v1 = bits.RotateLeft64(v1, 13)
+		v1 ^= v0
+		v0 = bits.RotateLeft64(v0, 32)
+		v2 += v3
+		v3 = bits.RotateLeft64(v3, 16)
+		v3 ^= v2
+		v0 += v3
+		v3 = bits.RotateLeft64(v3, 21)
+		v3 ^= v0
+		v2 += v1
+		v1 = bits.RotateLeft64(v1, 17)
+		v1 ^= v2
+		v2 = bits.RotateLeft64(v2, 32)
+	}
+
+	n := len(p)
+	for ; len(p) >= 8; p = p[8:] {
+		m := binary.LittleEndian.Uint64(p)
+		v3 ^= m
+		round()
+		round()
+		v0 ^= m
+	}
+
+	// Last block: remaining bytes and the length in the top byte.
+	m := uint64(n) << 56
+	for i := len(p) - 1; i >= 0; i-- {
+		m |= uint64(p[i]) << (8 * uint(i))
+	}
+	v3 ^= m
+	round()
+	round()
+	v0 ^= m
+
+	v2 ^= 0xff
+	round()
+	round()
+	round()
+	round()
+
+	return v0 ^ v1 ^ v2 ^ v3
+}
+
+// NewWithHasher returns a new Dict object that makes the Key.ID of its items with h,
+// instead of the default FNV. If h is nil, FNV is used.
+// vargs are the initial values, as in New().
+func NewWithHasher(h Hasher, vargs ...interface{}) *Dict {
+	d := New().SetHasher(h)
+	d.Update(vargs...)
+	return d
+}
+
+// SetHasher changes the Hasher that d uses to make the Key.ID of its items. If h is nil,
+// FNV is used. The items already in d are hashed again, keeping their order. Keys are
+// always hashed with the dict locked, so it's safe to call while d is in use. It can be
+// chained to any constructor, e.g., NewDefault(factory).SetHasher(h).
+// Returns d, or a new dict if d is nil.
+func (d *Dict) SetHasher(h Hasher) *Dict {
+	// Sanity: don't panic on nil dict, just create a new one.
+	if d == nil {
+		d = New()
+	}
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	d.hasher = h
+	if len(d.keys) == 0 {
+		return d
+	}
+
+	values := make(map[uint64]interface{}, len(d.keys))
+	for i := range d.keys {
+		v := d.values[d.keys[i].ID]
+		d.keys[i].ID = fnvString(d.keys[i].Name)
+		if h != nil {
+			d.keys[i].ID = h([]byte(d.keys[i].Name))
+		}
+		values[d.keys[i].ID] = v
+	}
+	d.values = values
+
+	return d
+}
+
+// getHasher returns the Hasher of d, or nil for FNV.
+func (d *Dict) getHasher() Hasher {
+	if d == nil {
+		return nil
+	}
+
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+
+	return d.hasher
+}
+
+// keyID returns the ID of the Key made from value using the Hasher of d, without
+// allocating for string and integer keys with the default hasher. The caller must hold
+// the lock, since the Hasher can be changed by SetHasher.
+// Returns the ID and true, or 0 and false if value is not a valid key.
+func (d *Dict) keyID(value interface{}) (uint64, bool) {
+	if d == nil {
+		return hashKey(value, nil)
+	}
+	return hashKey(value, d.hasher)
+}
+
+// makeKey is MakeKey using the Hasher of d. The caller must hold the lock.
+// Returns the Key and true, or false if value is not a valid key.
+func (d *Dict) makeKey(value interface{}) (Key, bool) {
+	if d == nil {
+		return makeKey(value, nil)
+	}
+	return makeKey(value, d.hasher)
+}
@@ -0,0 +1,184 @@
+// Copyright (c) 2025 srfrog - https://srfrog.dev
+// Use of this source code is governed by the license in the LICENSE file.
+
+package dict
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSipHash(t *testing.T) {
+	// Test vectors from the SipHash paper, with key 00 01 02 ... 0f and message 00 01 02 ...
+	k0, k1 := uint64(0x0706050403020100), uint64(0x0f0e0d0c0b0a0908)
+	msg := make([]byte, 16)
+	for i := range msg {
+		msg[i] = byte(i)
+	}
+
+	tests := []struct {
+		n    int
+		hash uint64
+	}{
+		{n: 0, hash: 0x726fdb47dd0e0e31},
+		{n: 7, hash: 0xab0200f58b01d137},
+		{n: 8, hash: 0x93f5f5799a932462},
+		{n: 15, hash: 0xa129ca6149be45e5},
+	}
+	h := NewSipHasher(k0, k1)
+	for _, tc := range tests {
+		require.Equal(t, tc.hash, h(msg[:tc.n]), "message length %d", tc.n)
+	}
+}
+
+func TestHashers(t *testing.T) {
+	require.Equal(t, MakeKey("key").ID, FNV([]byte("key")))
+
+	h := NewMapHasher()
+	require.Equal(t, h([]byte("key")), h([]byte("key")))
+	require.NotEqual(t, h([]byte("key")), h([]byte("other")))
+}
+
+func TestNewWithHasher(t *testing.T) {
+	hashers := []struct {
+		name string
+		h    Hasher
+	}{
+		{"default", nil},
+		{"fnv", FNV},
+		{"siphash", NewSipHasher(1, 2)},
+		{"maphash", NewMapHasher()},
+		{"custom", func(b []byte) uint64 { return FNV(b) ^ 0x5bd1e995 }},
+	}
+	for _, tc := range hashers {
+		t.Run(tc.name, func(t *testing.T) {
+			d := NewWithHasher(tc.h, map[string]int{"a": 1})
+			d.Set("bb", 2).Set(3, "three").Set("ccc", 4)
+			d.Del("a")
+			d.SortKeys(nil)
+
+			require.Equal(t, []string{"3", "bb", "ccc"}, d.Keys())
+			require.Equal(t, 2, d.Get("bb"))
+			require.Equal(t, "three", d.Get(3))
+			require.True(t, d.Key("ccc"))
+			require.False(t, d.Key("a"))
+
+			id, ok := d.GetKeyID("bb")
+			require.True(t, ok)
+			if tc.h != nil {
+				require.Equal(t, tc.h([]byte("bb")), id)
+			}
+
+			b, err := json.Marshal(d)
+			require.NoError(t, err)
+			require.Equal(t, `{"3":"three","bb":2,"ccc":4}`, string(b))
+
+			other := New().Set(3, "three").Set("bb", 2).Set("ccc", 4)
+			require.True(t, d.Equal(other))
+			require.True(t, other.Equal(d))
+			require.Empty(t, Diff(d, other))
+
+			c := d.Copy()
+			require.Equal(t, 4, c.Set("dd", 4).Get("dd"))
+			require.Equal(t, 2, d.Freeze().Get("bb"))
+			require.Equal(t, 2, d.Freeze().Dict().Get("bb"))
+
+			tx := d.Begin().Set("e", 5)
+			require.NoError(t, tx.Commit())
+			require.Equal(t, 5, d.Pop("e"))
+		})
+	}
+}
+
+func TestSetHasher(t *testing.T) {
+	h := NewSipHasher(1, 2)
+
+	d := NewDefault(func(string) interface{} { return 0 }).SetHasher(h)
+	require.Equal(t, 0, d.Get("a"))
+	require.Equal(t, h([]byte("a")), d.keys[0].ID)
+
+	d = NewWithCapacity(8).SetHasher(h).Set("a", 1)
+	require.Equal(t, 8, cap(d.keys))
+	require.Equal(t, h([]byte("a")), d.keys[0].ID)
+
+	// Existing items are hashed again, in order.
+	d = New().Set("a", 1).Set(2, "b").SetHasher(h)
+	require.Equal(t, []string{"a", "2"}, d.Keys())
+	require.Equal(t, 1, d.Get("a"))
+	require.Equal(t, "b", d.Get(2))
+	require.Equal(t, h([]byte("2")), d.keys[1].ID)
+
+	d.SetHasher(nil)
+	require.Equal(t, MakeKey("a").ID, d.keys[0].ID)
+	require.Equal(t, 1, d.Get("a"))
+
+	var nd *Dict
+	require.NotNil(t, nd.SetHasher(h))
+}
+
+func TestSetHasherConcurrent(t *testing.T) {
+	d := New()
+	for i := 0; i < 64; i++ {
+		d.Set(i, i)
+	}
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for i := 0; i < 100; i++ {
+			d.SetHasher(NewSipHasher(uint64(i), 1))
+			d.SetHasher(nil)
+		}
+	}()
+
+	// Keys are never missed while the dict is re-keyed.
+	var missed int
+	for i := 0; i < 2000; i++ {
+		if d.Get(i%64) != i%64 {
+			missed++
+		}
+		d.Set(i%64, i%64)
+	}
+	<-done
+	require.Zero(t, missed)
+	require.Equal(t, 64, d.Len())
+}
+
+func TestSetHasherTx(t *testing.T) {
+	d := New().Set("a", 1)
+	tx := d.Begin().Set("b", 2)
+	tx.Del("a")
+
+	// The changes of tx are hashed when committed.
+	d.SetHasher(NewSipHasher(1, 2))
+	require.Equal(t, 2, tx.Get("b"))
+	require.NoError(t, tx.Commit())
+	require.Equal(t, []string{"b"}, d.Keys())
+	require.Equal(t, 2, d.Get("b"))
+}
+
+func TestHasherNested(t *testing.T) {
+	h := NewSipHasher(1, 2)
+
+	// Embedded dicts made from JSON use the hasher of their parent.
+	d := NewWithHasher(h)
+	require.NoError(t, json.Unmarshal([]byte(`{"a":{"b":{"c":1}},"l":[{"x":1},2]}`), d))
+	a := d.Get("a").(*Dict)
+	require.Equal(t, h([]byte("b")), a.keys[0].ID)
+	require.Equal(t, h([]byte("c")), a.Get("b").(*Dict).keys[0].ID)
+	require.Equal(t, h([]byte("x")), d.Get("l").([]interface{})[0].(*Dict).keys[0].ID)
+
+	// Same for the dicts added by Merge and MergePatch.
+	d = NewWithHasher(h)
+	require.True(t, d.Merge(New().Set("m", New().Set("n", 1)), nil))
+	require.Equal(t, h([]byte("n")), d.Get("m").(*Dict).keys[0].ID)
+	require.NoError(t, d.MergePatch([]byte(`{"p":{"q":1}}`)))
+	require.Equal(t, h([]byte("q")), d.Get("p").(*Dict).keys[0].ID)
+
+	// Without a hasher, FNV is used.
+	d = New()
+	require.NoError(t, json.Unmarshal([]byte(`{"a":{"b":1}}`), d))
+	require.Equal(t, MakeKey("b").ID, d.Get("a").(*Dict).keys[0].ID)
+}
@@ -15,7 +15,11 @@ func (d *Dict) MarshalJSON() ([]byte, error) {
 	if d.IsEmpty() {
 		return []byte("null"), nil
 	}
+	return marshalItems(d.Items())
+}
 
+// marshalItems encodes the items received from ci as a JSON object.
+func marshalItems(ci <-chan Item) ([]byte, error) {
 	var (
 		err error
 		sb  strings.Builder
@@ -23,9 +27,12 @@ func (d *Dict) MarshalJSON() ([]byte, error) {
 	)
 
 	sb.WriteByte('{')
-	for item := range d.Items() {
+	for item := range ci {
 		var p []byte
 
+		if cnt > 0 {
+			sb.WriteByte(',')
+		}
 		sb.WriteByte('"')
 		sb.WriteString(item.Key.(string))
 		sb.WriteByte('"')
@@ -33,13 +40,13 @@ func (d *Dict) MarshalJSON() ([]byte, error) {
 
 		p, err = json.Marshal(item.Value)
 		if err != nil {
+			// Drain the channel so its goroutine can exit.
+			for range ci {
+			}
 			return nil, err
 		}
 		sb.Write(p)
 		cnt++
-		if cnt < d.Len() {
-			sb.WriteByte(',')
-		}
 	}
 	sb.WriteByte('}')
 
@@ -54,51 +61,67 @@ func (d *Dict) UnmarshalJSON(p []byte) error {
 		return err
 	}
 
+	h := d.getHasher()
+	for k, v := range m {
+		m[k] = fromJSON(v, h)
+	}
+	d.Update(m)
+
+	return nil
+}
+
+// fromJSON converts a value decoded by json.Unmarshal into the types used in a dict. The
+// embedded dicts use the Hasher h, so they are as safe as their parent from crafted keys.
+func fromJSON(v interface{}, h Hasher) interface{} {
 	// Unforunately json.Unmarshal will produce dynamic interface types for JSON arrays
 	// and objects - https://golang.org/pkg/encoding/json/#Unmarshal
 	// So here we try to convert []interface{} (JSON array) values into a slice if all the
 	// value types are the same. e.g., []string, []float64, etc...
-	// Also convert map[string]interface{} (JSON object) values into embedded dict objects.
-	for k, v := range m {
-		switch x := v.(type) {
-		// JSON array -> slice
-		case []interface{}:
-			kind, ok := hasSameKind(x)
-			if !ok {
-				break
+	// Also convert map[string]interface{} (JSON object) values into embedded dict objects,
+	// including the ones in mixed arrays.
+	switch x := v.(type) {
+	// JSON array -> slice
+	case []interface{}:
+		kind, ok := hasSameKind(x)
+		if !ok {
+			for i := range x {
+				x[i] = fromJSON(x[i], h)
+			}
+			break
+		}
+		switch kind {
+		case reflect.Bool:
+			var bs []bool
+			for i := range x {
+				bv, _ := x[i].(bool)
+				bs = append(bs, bv)
 			}
-			switch kind {
-			case reflect.Bool:
-				var bs []bool
-				for i := range x {
-					bv, _ := x[i].(bool)
-					bs = append(bs, bv)
-				}
-				m[k] = bs
-			case reflect.Float64:
-				var fs []float64
-				for i := range x {
-					fv, _ := x[i].(float64)
-					fs = append(fs, fv)
-				}
-				m[k] = fs
-			case reflect.String:
-				var ss []string
-				for i := range x {
-					sv, _ := x[i].(string)
-					ss = append(ss, sv)
-				}
-				m[k] = ss
+			return bs
+		case reflect.Float64:
+			var fs []float64
+			for i := range x {
+				fv, _ := x[i].(float64)
+				fs = append(fs, fv)
 			}
+			return fs
+		case reflect.String:
+			var ss []string
+			for i := range x {
+				sv, _ := x[i].(string)
+				ss = append(ss, sv)
+			}
+			return ss
+		}
 
-		// JSON object -> dict
-		case map[string]interface{}:
-			m[k] = New(x)
+	// JSON object -> dict
+	case map[string]interface{}:
+		d := New().SetHasher(h)
+		for k, v := range x {
+			d.Set(k, fromJSON(v, h))
 		}
+		return d
 	}
-	d.Update(m)
-
-	return nil
+	return v
 }
 
 func hasSameKind(a []interface{}) (reflect.Kind, bool) {
@@ -3,8 +3,12 @@
 
 package dict
 
-import (
-	"hash/fnv"
+import "strconv"
+
+// FNV-1a 64 bit constants, as in hash/fnv.
+const (
+	fnvOffset64 = 14695981039346656037
+	fnvPrime64  = 1099511628211
 )
 
 // Key represents a key value. Keys are used to order the items in a dict.
@@ -35,25 +39,87 @@ func isValidKeyType(t interface{}) bool {
 // int, uint, string, or that implements Stringer.
 // Returns a new Key object if successful, otherwise returns nil.
 func MakeKey(value interface{}) *Key {
-	var name string
+	k, ok := makeKey(value, nil)
+	if !ok {
+		return nil
+	}
+	return &k
+}
 
+// makeKey is MakeKey hashing with h, or FNV if h is nil. It returns the Key by value, so
+// it doesn't escape to the heap.
+// Returns the Key and true, or false if value is not a valid key.
+func makeKey(value interface{}, h Hasher) (Key, bool) {
 	if !isValidKeyType(value) {
-		return nil
+		return Key{}, false
 	}
 
-	name = toString(value)
+	name := toString(value)
 	if name == "" {
-		return nil
+		return Key{}, false
 	}
 
-	h := fnv.New64a()
-	_, err := h.Write([]byte(name))
-	if err != nil {
-		return nil
+	if h == nil {
+		return Key{ID: fnvString(name), Name: name}, true
+	}
+	return Key{ID: h([]byte(name)), Name: name}, true
+}
+
+// fnvString returns the FNV-1a hash of s, same as hash/fnv but without allocating.
+func fnvString(s string) uint64 {
+	h := uint64(fnvOffset64)
+	for i := 0; i < len(s); i++ {
+		h ^= uint64(s[i])
+		h *= fnvPrime64
+	}
+	return h
+}
+
+// fnvBytes returns the FNV-1a hash of b, same as hash/fnv but without allocating.
+func fnvBytes(b []byte) uint64 {
+	h := uint64(fnvOffset64)
+	for i := range b {
+		h ^= uint64(b[i])
+		h *= fnvPrime64
+	}
+	return h
+}
+
+// hashKey returns the ID of the Key made from value with h, or FNV if h is nil, without
+// making the Key. It's used for lookups, which don't need the key name. With the default
+// hasher, string and integer keys don't allocate.
+// Returns the ID and true, or 0 and false if value is not a valid key.
+func hashKey(value interface{}, h Hasher) (uint64, bool) {
+	var buf [24]byte
+	var b []byte
+
+	switch v := value.(type) {
+	case string:
+		if v == "" {
+			return 0, false
+		}
+		if h == nil {
+			return fnvString(v), true
+		}
+		return h([]byte(v)), true
+	case int, int8, int16, int32, int64:
+		b = strconv.AppendInt(buf[:0], toInt64(v), 10)
+	case uint, uint8, uint16, uint32, uint64:
+		b = strconv.AppendUint(buf[:0], toUint64(v), 10)
+	default:
+		k := MakeKey(value)
+		if k == nil {
+			return 0, false
+		}
+		if h == nil {
+			return k.ID, true
+		}
+		return h([]byte(k.Name)), true
 	}
 
-	return &Key{
-		ID:   h.Sum64(),
-		Name: name,
+	if h == nil {
+		return fnvBytes(b), true
 	}
+	// Pass a copy, so buf doesn't escape to the heap.
+	return h(append([]byte(nil), b...)), true
 }
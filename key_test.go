@@ -47,3 +47,67 @@ func TestMakeKey(t *testing.T) {
 		t.Run(tc.name, tc.fn)
 	}
 }
+
+func TestKeyIDMatchesMakeKey(t *testing.T) {
+	keys := []interface{}{
+		"a", "key", 0, 1, -1, 255, int64(1 << 40), int8(-8), int16(16), int32(-32), int64(64),
+		uint(1), uint8(8), uint16(16), uint32(32), uint64(1 << 63), 1.5, float32(2.25),
+	}
+	d := dict.New()
+	for _, key := range keys {
+		d.Set(key, true)
+	}
+	for _, key := range keys {
+		id, ok := d.GetKeyID(key)
+		require.True(t, ok, "key %v", key)
+		require.Equal(t, dict.MakeKey(key).ID, id, "key %v", key)
+	}
+}
+
+func TestKeyLookupAllocs(t *testing.T) {
+	d := dict.NewWithCapacity(2)
+	d.Set("name", 1).Set(123456, 2)
+
+	// Box the keys beforehand, only the lookups are measured.
+	var skey, ikey, missing interface{} = "name", 123456, uint64(7)
+	allocs := testing.AllocsPerRun(100, func() {
+		_ = d.Get(skey)
+		_ = d.Get(ikey)
+		_ = d.Get(missing)
+		_ = d.Key(skey)
+		_, _ = d.GetKeyID(ikey)
+		_ = d.Del(missing)
+	})
+	require.Zero(t, allocs)
+
+	// Replacing the value of an existing key doesn't allocate either.
+	var one interface{} = 1
+	allocs = testing.AllocsPerRun(100, func() {
+		d.Set(skey, one)
+		d.Set(ikey, one)
+	})
+	require.Zero(t, allocs)
+
+	// Batch lookups allocate their result slices, but nothing per key.
+	keys := make([]interface{}, 64)
+	for i := range keys {
+		keys[i] = []interface{}{skey, ikey, missing}[i%3]
+	}
+	few := testing.AllocsPerRun(100, func() { _, _ = d.GetMany(keys[:48]...) })
+	allocs = testing.AllocsPerRun(100, func() { _, _ = d.GetMany(keys...) })
+	require.Equal(t, few, allocs)
+
+	f := d.Freeze()
+	c := dict.NewConcurrent(0, d)
+	allocs = testing.AllocsPerRun(100, func() {
+		_ = f.Get(skey)
+		_ = f.Key(ikey)
+		_ = c.Get(skey)
+		_ = c.Key(ikey)
+	})
+	require.Zero(t, allocs)
+
+	few = testing.AllocsPerRun(100, func() { _ = d.DelMany(keys[:48]...) })
+	allocs = testing.AllocsPerRun(100, func() { _ = d.DelMany(keys...) })
+	require.Equal(t, few, allocs)
+}
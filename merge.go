@@ -0,0 +1,123 @@
+// Copyright (c) 2025 srfrog - https://srfrog.dev
+// Use of this source code is governed by the license in the LICENSE file.
+
+package dict
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+)
+
+// SliceStrategy defines how Merge combines two slice values with the same key.
+type SliceStrategy int
+
+// Slice merge strategies.
+const (
+	// SliceReplace replaces the existing slice with the incoming one.
+	SliceReplace SliceStrategy = iota
+	// SliceAppend appends the incoming elements to the existing slice.
+	SliceAppend
+	// SliceUnion appends the incoming elements that are not already in the existing slice.
+	SliceUnion
+)
+
+// MergeOptions are the settings used by Merge.
+// Shallow disables the recursive merge of embedded dicts, which are then replaced whole
+// just like Update does.
+// NullDelete removes the keys whose incoming value is nil, as in RFC 7386.
+// Slices is the strategy used to combine slice values.
+type MergeOptions struct {
+	Shallow    bool
+	NullDelete bool
+	Slices     SliceStrategy
+}
+
+// Merge combines the items of other into d. Embedded dicts found in both are merged
+// recursively, other values in d are replaced by those in other, and new keys are added in
+// the insertion order of other. If opts is nil the default options are used: deep merge,
+// nil values are stored, and slices are replaced.
+// Merging is meant for layered values, e.g., defaults.Merge(file, nil).
+// Returns true if any changes were made.
+func (d *Dict) Merge(other *Dict, opts *MergeOptions) bool {
+	if d == nil || other == nil {
+		return false
+	}
+	if opts == nil {
+		opts = &MergeOptions{}
+	}
+
+	var changed bool
+	for item := range other.Items() {
+		if item.Value == nil && opts.NullDelete {
+			changed = d.Del(item.Key) || changed
+			continue
+		}
+
+		curr, _ := d.lookup(item.Key)
+		if od, ok := item.Value.(*Dict); ok && !opts.Shallow {
+			cd, ok := curr.(*Dict)
+			if !ok {
+				// Merge into an empty dict, so nested nils are also dropped.
+				cd = New().SetHasher(d.getHasher())
+				d.Set(item.Key, cd)
+				changed = true
+			}
+			changed = cd.Merge(od, opts) || changed
+			continue
+		}
+
+		value := mergeSlices(curr, item.Value, opts.Slices)
+		ver := d.Version()
+		d.Set(item.Key, value)
+		changed = changed || ver != d.Version()
+	}
+	return changed
+}
+
+// MergePatch applies the JSON Merge Patch document p to d, as defined in RFC 7386.
+// JSON objects are merged recursively, null values remove keys and arrays are replaced.
+// Returns an error if p is not a JSON object.
+func (d *Dict) MergePatch(p []byte) error {
+	var v interface{}
+	if err := json.Unmarshal(p, &v); err != nil {
+		return err
+	}
+	patch, ok := fromJSON(v, d.getHasher()).(*Dict)
+	if !ok {
+		return fmt.Errorf("%w: merge patch must be a JSON object", ErrInvalidPatch)
+	}
+	d.Merge(patch, &MergeOptions{NullDelete: true})
+	return nil
+}
+
+// mergeSlices combines the slices a and b with strategy s. If either value isn't a slice,
+// or s is SliceReplace, b is returned.
+func mergeSlices(a, b interface{}, s SliceStrategy) interface{} {
+	av, bv := reflect.ValueOf(a), reflect.ValueOf(b)
+	if s == SliceReplace || av.Kind() != reflect.Slice || bv.Kind() != reflect.Slice {
+		return b
+	}
+
+	t := av.Type()
+	if bv.Type() != t {
+		t = reflect.TypeOf([]interface{}{})
+	}
+	res := reflect.MakeSlice(t, 0, av.Len()+bv.Len())
+	for i := 0; i < av.Len(); i++ {
+		res = reflect.Append(res, av.Index(i))
+	}
+
+L:
+	for i := 0; i < bv.Len(); i++ {
+		if s == SliceUnion {
+			for j := 0; j < res.Len(); j++ {
+				if valuesEqual(res.Index(j).Interface(), bv.Index(i).Interface()) {
+					continue L
+				}
+			}
+		}
+		res = reflect.Append(res, bv.Index(i))
+	}
+	return res.Interface()
+}
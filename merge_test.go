@@ -0,0 +1,131 @@
+// Copyright (c) 2025 srfrog - https://srfrog.dev
+// Use of this source code is governed by the license in the LICENSE file.
+
+package dict
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func newMergeDefaults() *Dict {
+	return New().
+		Set("host", "localhost").
+		Set("port", 8080).
+		Set("tags", []string{"a", "b"}).
+		Set("db", New().Set("user", "admin").Set("pool", 4))
+}
+
+func TestMerge(t *testing.T) {
+	tests := []struct {
+		name string
+		opts *MergeOptions
+		out  string
+	}{
+		{"default", nil,
+			`{"host":"example.com","port":8080,"tags":["b","c"],"db":{"user":"admin","pool":8,"ssl":true},"debug":null}`},
+		{"shallow", &MergeOptions{Shallow: true},
+			`{"host":"example.com","port":8080,"tags":["b","c"],"db":{"pool":8,"ssl":true},"debug":null}`},
+		{"null delete", &MergeOptions{NullDelete: true},
+			`{"host":"example.com","port":8080,"tags":["b","c"],"db":{"user":"admin","pool":8,"ssl":true}}`},
+		{"slice append", &MergeOptions{Slices: SliceAppend},
+			`{"host":"example.com","port":8080,"tags":["a","b","b","c"],"db":{"user":"admin","pool":8,"ssl":true},"debug":null}`},
+		{"slice union", &MergeOptions{Slices: SliceUnion},
+			`{"host":"example.com","port":8080,"tags":["a","b","c"],"db":{"user":"admin","pool":8,"ssl":true},"debug":null}`},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			d := newMergeDefaults()
+			other := New().
+				Set("host", "example.com").
+				Set("tags", []string{"b", "c"}).
+				Set("db", New().Set("pool", 8).Set("ssl", true)).
+				Set("debug", nil)
+
+			require.True(t, d.Merge(other, tc.opts))
+			b, err := json.Marshal(d)
+			require.NoError(t, err)
+			require.JSONEq(t, tc.out, string(b))
+			require.Equal(t, []string{"host", "port", "tags", "db"}, d.Keys()[:4])
+		})
+	}
+}
+
+func TestMergeNoChange(t *testing.T) {
+	d := newMergeDefaults()
+	require.False(t, d.Merge(New(), nil))
+	require.False(t, d.Merge(nil, nil))
+	require.False(t, d.Merge(New().Set("port", 8080).Set("db", New().Set("pool", 4)), nil))
+	require.False(t, d.Merge(New().Set("missing", nil), &MergeOptions{NullDelete: true}))
+}
+
+func TestMergeDefault(t *testing.T) {
+	d := NewDefault(func(string) interface{} { return []string{"x"} })
+	require.True(t, d.Merge(New().Set("tags", []string{"y"}), &MergeOptions{Slices: SliceAppend}))
+	require.Equal(t, []string{"y"}, d.Get("tags"))
+
+	// Reading d doesn't add default values.
+	d = NewDefault(func(string) interface{} { return 0 }).Set("b", 1)
+	require.Len(t, Diff(New().Set("a", 1), d), 2)
+	require.False(t, New().Set("a", 1).Equal(d))
+	require.Equal(t, []string{"b"}, d.Keys())
+}
+
+func TestMergeLayers(t *testing.T) {
+	file := New().Set("db", New().Set("user", "app"))
+	env := New().Set("db", New().Set("pass", "secret"))
+
+	d := newMergeDefaults()
+	d.Merge(file, nil)
+	d.Merge(env, nil)
+
+	db := d.Get("db").(*Dict)
+	require.Equal(t, []string{"user", "pool", "pass"}, db.Keys())
+	require.Equal(t, "app", db.Get("user"))
+
+	// The layers are not changed by merging.
+	require.Equal(t, []string{"user"}, file.Get("db").(*Dict).Keys())
+}
+
+func TestMergePatch(t *testing.T) {
+	// Example from RFC 7386, section 3.
+	d := New()
+	require.NoError(t, json.Unmarshal([]byte(`{
+		"title": "Goodbye!",
+		"author": {"givenName": "John", "familyName": "Doe"},
+		"tags": ["example", "sample"],
+		"content": "This will be unchanged"
+	}`), d))
+
+	require.NoError(t, d.MergePatch([]byte(`{
+		"title": "Hello!",
+		"phoneNumber": "+01-123-456-7890",
+		"author": {"familyName": null},
+		"tags": ["example"]
+	}`)))
+
+	b, err := json.Marshal(d)
+	require.NoError(t, err)
+	require.JSONEq(t, `{
+		"title": "Hello!",
+		"author": {"givenName": "John"},
+		"tags": ["example"],
+		"content": "This will be unchanged",
+		"phoneNumber": "+01-123-456-7890"
+	}`, string(b))
+
+	// Nested nulls in a new object are dropped.
+	require.NoError(t, d.MergePatch([]byte(`{"content": {"a": {"b": null, "c": 1}}}`)))
+	b, err = json.Marshal(d.Get("content"))
+	require.NoError(t, err)
+	require.JSONEq(t, `{"a": {"c": 1}}`, string(b))
+}
+
+func TestMergePatchErr(t *testing.T) {
+	d := New()
+	require.Error(t, d.MergePatch([]byte(`{`)))
+	require.True(t, errors.Is(d.MergePatch([]byte(`[1, 2]`)), ErrInvalidPatch))
+}
@@ -0,0 +1,329 @@
+// Copyright (c) 2025 srfrog - https://srfrog.dev
+// Use of this source code is governed by the license in the LICENSE file.
+
+package dict
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+	"sync"
+	"sync/atomic"
+)
+
+// MultiDict is a dict that allows repeated keys, like HTTP query strings, headers and form
+// data. Every key-value pair is kept in insertion order. Keys are hashed with MakeKey, so
+// the same key types as Dict are supported.
+type MultiDict struct {
+	size, version int64
+	items         []multiItem
+	counts        map[uint64]int
+	mu            sync.RWMutex
+}
+
+type multiItem struct {
+	key   *Key
+	value interface{}
+}
+
+// NewMultiDict returns a new MultiDict object.
+// vargs are the initial values, as in New(). Items with repeated keys are all added.
+func NewMultiDict(vargs ...interface{}) *MultiDict {
+	d := &MultiDict{counts: make(map[uint64]int)}
+	for i := range vargs {
+		if other, ok := vargs[i].(itemsSource); ok {
+			for item := range other.Items() {
+				d.Add(item.Key, item.Value)
+			}
+			continue
+		}
+		for item := range toIterable(vargs[i]) {
+			if item.Key == nil {
+				item.Key = d.Len()
+			}
+			d.Add(item.Key, item.Value)
+		}
+	}
+	return d
+}
+
+// FromURLValues returns a new MultiDict with the values of v. The keys are sorted, since
+// url.Values is a map, and the values of each key keep their order.
+func FromURLValues(v url.Values) *MultiDict {
+	return fromStringsMap(v)
+}
+
+// FromHeader returns a new MultiDict with the values of h. The keys are sorted, since
+// http.Header is a map, and the values of each key keep their order.
+func FromHeader(h http.Header) *MultiDict {
+	return fromStringsMap(h)
+}
+
+func fromStringsMap(m map[string][]string) *MultiDict {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	d := NewMultiDict()
+	for _, key := range keys {
+		for _, value := range m[key] {
+			d.Add(key, value)
+		}
+	}
+	return d
+}
+
+// Version returns the version of the dictionary. The version is increased after every
+// change to dict items.
+// Returns version, which is zero (0) initially.
+func (d *MultiDict) Version() int {
+	return int(atomic.LoadInt64(&d.version))
+}
+
+// Len returns the number of key-value pairs in the dict.
+func (d *MultiDict) Len() int {
+	return int(atomic.LoadInt64(&d.size))
+}
+
+// IsEmpty returns true if the dict is empty, false otherwise.
+func (d *MultiDict) IsEmpty() bool {
+	return d == nil || d.Len() == 0
+}
+
+// Add appends a new key-value pair to the dict, keeping any existing values of the key.
+func (d *MultiDict) Add(key, value interface{}) *MultiDict {
+	// Sanity: don't panic on nil dict, just create a new one.
+	if d == nil {
+		d = NewMultiDict()
+	}
+
+	k := MakeKey(key)
+	if k == nil {
+		return d
+	}
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if d.counts == nil {
+		d.counts = make(map[uint64]int)
+	}
+	d.items = append(d.items, multiItem{key: k, value: value})
+	d.counts[k.ID]++
+	atomic.AddInt64(&d.size, 1)
+	atomic.AddInt64(&d.version, 1)
+
+	return d
+}
+
+// Key returns true if key is in dict d, false otherwise.
+func (d *MultiDict) Key(key interface{}) bool {
+	k := MakeKey(key)
+	if k == nil || d.IsEmpty() {
+		return false
+	}
+
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+
+	return d.counts[k.ID] > 0
+}
+
+// GetFirst retrieves the first value added for key. If alt value is passed, it will be
+// used as default value if no item is found.
+// Returns the first value matching key, otherwise nil or alt if given.
+func (d *MultiDict) GetFirst(key interface{}, alt ...interface{}) interface{} {
+	if k := MakeKey(key); k != nil && !d.IsEmpty() {
+		d.mu.RLock()
+		defer d.mu.RUnlock()
+
+		for i := range d.items {
+			if d.items[i].key.ID == k.ID {
+				return d.items[i].value
+			}
+		}
+	}
+	if alt != nil {
+		return alt[0]
+	}
+	return nil
+}
+
+// GetAll retrieves all the values of key, in insertion order.
+// Returns the values, or nil if key is not found.
+func (d *MultiDict) GetAll(key interface{}) []interface{} {
+	k := MakeKey(key)
+	if k == nil || d.IsEmpty() {
+		return nil
+	}
+
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+
+	var values []interface{}
+	for i := range d.items {
+		if d.items[i].key.ID == k.ID {
+			values = append(values, d.items[i].value)
+		}
+	}
+	return values
+}
+
+// SetAll replaces all the values of key with values. The new values take the position of
+// the first existing value of key, or are appended if key is not found. If values is
+// empty, the key is removed.
+func (d *MultiDict) SetAll(key interface{}, values ...interface{}) *MultiDict {
+	// Sanity: don't panic on nil dict, just create a new one.
+	if d == nil {
+		d = NewMultiDict()
+	}
+
+	k := MakeKey(key)
+	if k == nil {
+		return d
+	}
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if d.counts == nil {
+		d.counts = make(map[uint64]int)
+	}
+
+	pos := -1
+	items := make([]multiItem, 0, len(d.items)+len(values))
+	for i := range d.items {
+		if d.items[i].key.ID != k.ID {
+			items = append(items, d.items[i])
+			continue
+		}
+		if pos < 0 {
+			pos = len(items)
+			k = d.items[i].key
+		}
+	}
+	if pos < 0 {
+		pos = len(items)
+	}
+
+	added := make([]multiItem, len(values))
+	for i := range values {
+		added[i] = multiItem{key: k, value: values[i]}
+	}
+	d.items = append(items[:pos], append(added, items[pos:]...)...)
+
+	d.counts[k.ID] = len(values)
+	if len(values) == 0 {
+		delete(d.counts, k.ID)
+	}
+	atomic.StoreInt64(&d.size, int64(len(d.items)))
+	atomic.AddInt64(&d.version, 1)
+
+	return d
+}
+
+// DelAll removes all the values of key.
+// Returns true if any items were found and removed, false otherwise.
+func (d *MultiDict) DelAll(key interface{}) bool {
+	if !d.Key(key) {
+		return false
+	}
+	d.SetAll(key)
+	return true
+}
+
+// Keys returns a string slice of the unique keys, in order of first appearance, or nil if
+// dict is empty.
+func (d *MultiDict) Keys() []string {
+	if d.IsEmpty() {
+		return nil
+	}
+
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+
+	seen := make(map[uint64]bool, len(d.counts))
+	keys := make([]string, 0, len(d.counts))
+	for i := range d.items {
+		if !seen[d.items[i].key.ID] {
+			seen[d.items[i].key.ID] = true
+			keys = append(keys, d.items[i].key.Name)
+		}
+	}
+	return keys
+}
+
+// Items returns a channel of all the key-value pairs, in insertion order.
+func (d *MultiDict) Items() <-chan Item {
+	ci := make(chan Item)
+	if d.IsEmpty() {
+		close(ci)
+		return ci
+	}
+
+	// Avoid lock contention
+	d.mu.RLock()
+	items := make([]Item, len(d.items))
+	for i := range d.items {
+		items[i] = Item{Key: d.items[i].key.Name, Value: d.items[i].value}
+	}
+	d.mu.RUnlock()
+
+	go func() {
+		defer close(ci)
+		for _, item := range items {
+			ci <- item
+		}
+	}()
+
+	return ci
+}
+
+// URLValues returns the items of d as url.Values. Values are formatted with fmt.Sprint.
+func (d *MultiDict) URLValues() url.Values {
+	v := url.Values{}
+	for item := range d.Items() {
+		v.Add(item.Key.(string), fmt.Sprint(item.Value))
+	}
+	return v
+}
+
+// Header returns the items of d as http.Header, with canonical header keys. Values are
+// formatted with fmt.Sprint.
+func (d *MultiDict) Header() http.Header {
+	h := http.Header{}
+	for item := range d.Items() {
+		h.Add(item.Key.(string), fmt.Sprint(item.Value))
+	}
+	return h
+}
+
+// String implements the fmt.Stringer interface to print d similar to a Python dict, with
+// repeated keys.
+func (d *MultiDict) String() string {
+	items := make([]string, 0, d.Len())
+	for item := range d.Items() {
+		items = append(items, fmt.Sprintf("%v: %#v", item.Key, item.Value))
+	}
+	return "{" + strings.Join(items, ", ") + "}"
+}
+
+// MarshalJSON implements the json.MarshalJSON interface.
+// The JSON representation of a multi dict is a JSON object with an array of values for
+// each key.
+func (d *MultiDict) MarshalJSON() ([]byte, error) {
+	if d.IsEmpty() {
+		return []byte("null"), nil
+	}
+
+	m := New()
+	for _, key := range d.Keys() {
+		m.Set(key, d.GetAll(key))
+	}
+	return json.Marshal(m)
+}
@@ -0,0 +1,103 @@
+// Copyright (c) 2025 srfrog - https://srfrog.dev
+// Use of this source code is governed by the license in the LICENSE file.
+
+package dict
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestMultiDict(t *testing.T) {
+	d := NewMultiDict()
+	d.Add("tag", "go").Add("page", 1).Add("tag", "dict").Add(1, "one").Add("tag", "json")
+	d.Add(nil, "ignored")
+
+	require.Equal(t, 5, d.Len())
+	require.Equal(t, []string{"tag", "page", "1"}, d.Keys())
+	require.True(t, d.Key("page"))
+	require.False(t, d.Key("missing"))
+
+	require.Equal(t, "go", d.GetFirst("tag"))
+	require.Equal(t, "one", d.GetFirst("1"))
+	require.Nil(t, d.GetFirst("missing"))
+	require.Equal(t, "alt", d.GetFirst("missing", "alt"))
+	require.Equal(t, []interface{}{"go", "dict", "json"}, d.GetAll("tag"))
+	require.Nil(t, d.GetAll("missing"))
+
+	require.Equal(t, `{tag: "go", page: 1, tag: "dict", 1: "one", tag: "json"}`, d.String())
+
+	b, err := json.Marshal(d)
+	require.NoError(t, err)
+	require.JSONEq(t, `{"tag": ["go", "dict", "json"], "page": [1], "1": ["one"]}`, string(b))
+
+	// Dict keeps the last value of each key.
+	require.Equal(t, "json", New(d).Get("tag"))
+}
+
+func TestMultiDictSetAll(t *testing.T) {
+	d := NewMultiDict().Add("a", 1).Add("b", 2).Add("a", 3).Add("c", 4)
+
+	ver := d.Version()
+	d.SetAll("a", 10, 20, 30)
+	require.True(t, d.Version() > ver)
+	require.Equal(t, `{a: 10, a: 20, a: 30, b: 2, c: 4}`, d.String())
+	require.Equal(t, 5, d.Len())
+
+	d.SetAll("d", 5)
+	require.Equal(t, `{a: 10, a: 20, a: 30, b: 2, c: 4, d: 5}`, d.String())
+
+	require.True(t, d.DelAll("a"))
+	require.False(t, d.DelAll("a"))
+	require.False(t, d.Key("a"))
+	require.Equal(t, `{b: 2, c: 4, d: 5}`, d.String())
+	require.Equal(t, 3, d.Len())
+
+	d.SetAll("b")
+	require.False(t, d.Key("b"))
+	require.Equal(t, []string{"c", "d"}, d.Keys())
+}
+
+func TestMultiDictURLValues(t *testing.T) {
+	v, err := url.ParseQuery("q=go&tag=a&tag=b&page=2")
+	require.NoError(t, err)
+
+	d := FromURLValues(v)
+	require.Equal(t, []string{"page", "q", "tag"}, d.Keys())
+	require.Equal(t, []interface{}{"a", "b"}, d.GetAll("tag"))
+
+	d.Add("page", 3)
+	require.Equal(t, "page=2&page=3&q=go&tag=a&tag=b", d.URLValues().Encode())
+}
+
+func TestMultiDictHeader(t *testing.T) {
+	h := http.Header{}
+	h.Add("Accept", "text/html")
+	h.Add("Accept", "application/json")
+	h.Add("X-Request-Id", "42")
+
+	d := FromHeader(h)
+	require.Equal(t, []string{"Accept", "X-Request-Id"}, d.Keys())
+	require.Equal(t, "text/html", d.GetFirst("Accept"))
+
+	d.Add("cache-control", "no-cache")
+	out := d.Header()
+	require.Equal(t, []string{"text/html", "application/json"}, out.Values("Accept"))
+	require.Equal(t, "no-cache", out.Get("Cache-Control"))
+	require.Len(t, out, 3)
+}
+
+func TestMultiDictInit(t *testing.T) {
+	d := NewMultiDict([]Item{{Key: "a", Value: 1}, {Key: "a", Value: 2}}, New().Set("b", 3))
+	require.Equal(t, []interface{}{1, 2}, d.GetAll("a"))
+	require.Equal(t, 3, d.GetFirst("b"))
+
+	var nd *MultiDict
+	require.Equal(t, 1, nd.Add("a", 1).Len())
+	require.Equal(t, 1, (&MultiDict{}).Add("a", 1).Len())
+	require.True(t, nd.IsEmpty())
+}
@@ -0,0 +1,203 @@
+// Copyright (c) 2025 srfrog - https://srfrog.dev
+// Use of this source code is governed by the license in the LICENSE file.
+
+package dict
+
+import (
+	"sort"
+	"sync/atomic"
+)
+
+// indexOf returns the position of the key with id in d.keys, or -1 if not found.
+// The caller must hold the lock.
+func (d *Dict) indexOf(id uint64) int {
+	for i := range d.keys {
+		if d.keys[i].ID == id {
+			return i
+		}
+	}
+	return -1
+}
+
+// Index returns the position of key in the order of d.
+// Returns the index starting at zero (0), or -1 if the key is not found.
+func (d *Dict) Index(key interface{}) int {
+	id, ok := d.GetKeyID(key)
+	if !ok {
+		return -1
+	}
+
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+
+	return d.indexOf(id)
+}
+
+// At returns the item at position i in the order of d. A negative i counts from the end,
+// so At(-1) is the last item.
+// Returns the item, or an empty Item with a nil key if i is out of range.
+func (d *Dict) At(i int) Item {
+	if d.IsEmpty() {
+		return Item{}
+	}
+
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+
+	if i < 0 {
+		i += len(d.keys)
+	}
+	if i < 0 || i >= len(d.keys) {
+		return Item{}
+	}
+	return Item{Key: d.keys[i].Name, Value: d.values[d.keys[i].ID]}
+}
+
+// InsertAt inserts an item at position i in the order of d, shifting the following items.
+// If the key is already in d, its value is replaced and it's moved to position i. A
+// negative i counts from the end, and i is limited to the range of valid positions.
+// Returns d.
+func (d *Dict) InsertAt(i int, key, value interface{}) *Dict {
+	// Sanity: don't panic on nil dict, just create a new one.
+	if d == nil {
+		d = New()
+	}
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	k, ok := d.makeKey(key)
+	if !ok {
+		return d
+	}
+
+	if idx := d.indexOf(k.ID); idx >= 0 {
+		k = d.keys[idx]
+		copy(d.keys[idx:], d.keys[idx+1:])
+		d.keys = d.keys[:len(d.keys)-1]
+	} else {
+		atomic.AddInt64(&d.size, 1)
+	}
+
+	if i < 0 {
+		i += len(d.keys) + 1
+	}
+	if i < 0 {
+		i = 0
+	}
+	if i > len(d.keys) {
+		i = len(d.keys)
+	}
+
+	d.keys = append(d.keys, Key{})
+	copy(d.keys[i+1:], d.keys[i:])
+	d.keys[i] = k
+	d.values[k.ID] = value
+	atomic.AddInt64(&d.version, 1)
+
+	return d
+}
+
+// MoveToEnd moves an existing key to the end of d, or to the beginning if last is false,
+// like Python OrderedDict.move_to_end().
+// Returns true if the key was found, false otherwise.
+func (d *Dict) MoveToEnd(key interface{}, last bool) bool {
+	id, ok := d.GetKeyID(key)
+	if !ok {
+		return false
+	}
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	idx := d.indexOf(id)
+	if idx < 0 {
+		return false
+	}
+
+	k := d.keys[idx]
+	if last {
+		copy(d.keys[idx:], d.keys[idx+1:])
+		d.keys[len(d.keys)-1] = k
+	} else {
+		copy(d.keys[1:idx+1], d.keys[:idx])
+		d.keys[0] = k
+	}
+	atomic.AddInt64(&d.version, 1)
+
+	return true
+}
+
+// PopItemFirst removes the first item added to the dict and returns it. If the dict is
+// empty, returns nil.
+func (d *Dict) PopItemFirst() *Item {
+	if d.IsEmpty() {
+		return nil
+	}
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if len(d.keys) == 0 {
+		return nil
+	}
+
+	key := d.keys[0]
+	value := d.values[key.ID]
+	d.deleteItem(0)
+
+	return &Item{
+		Key:   key.Name,
+		Value: value,
+	}
+}
+
+// Reverse reverses the order of the items in d.
+func (d *Dict) Reverse() {
+	if d.IsEmpty() {
+		return
+	}
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	for i, j := 0, len(d.keys)-1; i < j; i, j = i+1, j-1 {
+		d.keys[i], d.keys[j] = d.keys[j], d.keys[i]
+	}
+	atomic.AddInt64(&d.version, 1)
+}
+
+// SortBy sorts the items of d in place using less to compare items. The sort is stable,
+// so equal items keep their order. The dict is locked while sorting, so less must not
+// call methods of d.
+func (d *Dict) SortBy(less func(a, b Item) bool) {
+	if d.IsEmpty() {
+		return
+	}
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	items := make([]Item, len(d.keys))
+	for i, key := range d.keys {
+		items[i] = Item{Key: key.Name, Value: d.values[key.ID]}
+	}
+	sort.Stable(&itemSorter{keys: d.keys, items: items, less: func(i, j int) bool {
+		return less(items[i], items[j])
+	}})
+	atomic.AddInt64(&d.version, 1)
+}
+
+// itemSorter sorts the keys of a dict along with their items.
+type itemSorter struct {
+	keys  []Key
+	items []Item
+	less  func(i, j int) bool
+}
+
+func (s *itemSorter) Len() int           { return len(s.keys) }
+func (s *itemSorter) Less(i, j int) bool { return s.less(i, j) }
+func (s *itemSorter) Swap(i, j int) {
+	s.keys[i], s.keys[j] = s.keys[j], s.keys[i]
+	s.items[i], s.items[j] = s.items[j], s.items[i]
+}
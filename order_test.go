@@ -0,0 +1,108 @@
+// Copyright (c) 2025 srfrog - https://srfrog.dev
+// Use of this source code is governed by the license in the LICENSE file.
+
+package dict
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestIndexAt(t *testing.T) {
+	d := New("a", "b", "c")
+
+	tests := []struct {
+		key interface{}
+		idx int
+	}{
+		{key: 0, idx: 0},
+		{key: "1", idx: 1},
+		{key: 2, idx: 2},
+		{key: 3, idx: -1},
+		{key: nil, idx: -1},
+	}
+	for _, tc := range tests {
+		require.Equal(t, tc.idx, d.Index(tc.key))
+	}
+
+	require.Equal(t, Item{Key: "0", Value: "a"}, d.At(0))
+	require.Equal(t, Item{Key: "2", Value: "c"}, d.At(2))
+	require.Equal(t, Item{Key: "2", Value: "c"}, d.At(-1))
+	require.Equal(t, Item{Key: "0", Value: "a"}, d.At(-3))
+	require.Equal(t, Item{}, d.At(3))
+	require.Equal(t, Item{}, d.At(-4))
+	require.Equal(t, Item{}, New().At(0))
+}
+
+func TestInsertAt(t *testing.T) {
+	d := New().Set("a", 1).Set("b", 2)
+
+	ver := d.Version()
+	require.Equal(t, d, d.InsertAt(1, "x", 10))
+	require.Equal(t, []string{"a", "x", "b"}, d.Keys())
+	require.Equal(t, 3, d.Len())
+	require.True(t, d.Version() > ver)
+
+	d.InsertAt(0, "y", 20)
+	d.InsertAt(100, "z", 30)
+	d.InsertAt(-1, "w", 40)
+	require.Equal(t, []string{"y", "a", "x", "b", "z", "w"}, d.Keys())
+
+	// Existing keys are moved and updated.
+	d.InsertAt(0, "w", 41)
+	require.Equal(t, []string{"w", "y", "a", "x", "b", "z"}, d.Keys())
+	require.Equal(t, 41, d.Get("w"))
+	require.Equal(t, 6, d.Len())
+
+	d.InsertAt(0, nil, 0)
+	require.Equal(t, 6, d.Len())
+
+	var nd *Dict
+	require.Equal(t, []string{"a"}, nd.InsertAt(0, "a", 1).Keys())
+}
+
+func TestMoveToEnd(t *testing.T) {
+	d := New().Set("a", 1).Set("b", 2).Set("c", 3)
+
+	require.True(t, d.MoveToEnd("a", true))
+	require.Equal(t, []string{"b", "c", "a"}, d.Keys())
+	require.True(t, d.MoveToEnd("c", false))
+	require.Equal(t, []string{"c", "b", "a"}, d.Keys())
+	require.True(t, d.MoveToEnd("c", false))
+	require.Equal(t, []string{"c", "b", "a"}, d.Keys())
+	require.False(t, d.MoveToEnd("x", true))
+	require.Equal(t, []interface{}{3, 2, 1}, d.Values())
+}
+
+func TestPopItemFirst(t *testing.T) {
+	d := New(1, 2)
+
+	require.Equal(t, &Item{Key: "0", Value: 1}, d.PopItemFirst())
+	require.Equal(t, 1, d.Len())
+	require.Equal(t, &Item{Key: "1", Value: 2}, d.PopItemFirst())
+	require.Nil(t, d.PopItemFirst())
+	require.True(t, d.IsEmpty())
+}
+
+func TestReverse(t *testing.T) {
+	d := New(1, 2, 3, 4)
+
+	d.Reverse()
+	require.Equal(t, []string{"3", "2", "1", "0"}, d.Keys())
+	require.Equal(t, []interface{}{4, 3, 2, 1}, d.Values())
+	require.Equal(t, 3, d.Get(2))
+
+	New().Reverse()
+}
+
+func TestSortBy(t *testing.T) {
+	d := New().Set("a", 3).Set("b", 1).Set("c", 2).Set("d", 1)
+
+	d.SortBy(func(a, b Item) bool { return a.Value.(int) < b.Value.(int) })
+	require.Equal(t, []string{"b", "d", "c", "a"}, d.Keys())
+	require.Equal(t, 3, d.Get("a"))
+
+	d.SortBy(func(a, b Item) bool { return a.Key.(string) > b.Key.(string) })
+	require.Equal(t, []string{"d", "c", "b", "a"}, d.Keys())
+}
@@ -0,0 +1,585 @@
+// Copyright (c) 2025 srfrog - https://srfrog.dev
+// Use of this source code is governed by the license in the LICENSE file.
+
+package dict
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"sync/atomic"
+)
+
+// Errors returned when applying a JSON Patch.
+var (
+	ErrInvalidPatch = errors.New("dict: invalid patch")
+	ErrInvalidPath  = errors.New("dict: invalid path")
+	ErrPathNotFound = errors.New("dict: path not found")
+	ErrTestFailed   = errors.New("dict: test failed")
+)
+
+// Operation is a single JSON Patch operation as defined in RFC 6902.
+// Op is one of "add", "remove", "replace", "move", "copy" or "test".
+// Path and From are JSON Pointers (RFC 6901) into the dict tree.
+// Value is the operand value of "add", "replace" and "test".
+type Operation struct {
+	Op    string
+	Path  string
+	From  string
+	Value interface{}
+}
+
+// Patch is a JSON Patch document, a list of operations applied in order.
+type Patch []Operation
+
+// MarshalJSON implements the json.Marshaler interface. Only the members used by the
+// operation are encoded.
+func (op Operation) MarshalJSON() ([]byte, error) {
+	m := map[string]interface{}{
+		"op":   op.Op,
+		"path": op.Path,
+	}
+	switch op.Op {
+	case "add", "replace", "test":
+		m["value"] = op.Value
+	case "move", "copy":
+		m["from"] = op.From
+	}
+	return json.Marshal(m)
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface. JSON objects in the operation
+// value are decoded as embedded dicts.
+func (op *Operation) UnmarshalJSON(p []byte) error {
+	var raw struct {
+		Op    string          `json:"op"`
+		Path  *string         `json:"path"`
+		From  string          `json:"from"`
+		Value json.RawMessage `json:"value"`
+	}
+	if err := json.Unmarshal(p, &raw); err != nil {
+		return err
+	}
+	if raw.Path == nil {
+		return fmt.Errorf("%w: missing path", ErrInvalidPatch)
+	}
+
+	var value interface{}
+	if raw.Value != nil {
+		if err := json.Unmarshal(raw.Value, &value); err != nil {
+			return err
+		}
+	}
+
+	*op = Operation{
+		Op:    raw.Op,
+		Path:  *raw.Path,
+		From:  raw.From,
+		Value: fromJSON(value, nil),
+	}
+	return nil
+}
+
+// DecodePatch parses a JSON Patch document.
+// Returns the patch, or an error if the document is not a valid JSON array of operations.
+func DecodePatch(p []byte) (Patch, error) {
+	var patch Patch
+	if err := json.Unmarshal(p, &patch); err != nil {
+		return nil, err
+	}
+	return patch, nil
+}
+
+// ApplyPatch applies the operations of patch to d in order. The patch is atomic: if any
+// operation fails, d is left unchanged and the error is returned. On success the version
+// of d is increased once. Embedded dicts are changed in place, so references to them
+// stay attached to d.
+func (d *Dict) ApplyPatch(patch Patch) error {
+	if d == nil {
+		return ErrInvalidPatch
+	}
+	if len(patch) == 0 {
+		return nil
+	}
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	for {
+		// Apply the patch on a private copy of the tree, so a failed operation leaves d
+		// untouched. Embedded dicts are copied once, even if found under many keys.
+		pc := &patchCopy{root: d, copies: make(map[*Dict]*Dict), versions: make(map[*Dict]int)}
+		root := pc.dict(d)
+		doc, err := applyPatch(root, patch)
+		if err != nil {
+			return err
+		}
+
+		// Then write the changed copies back to their embedded dicts, unless one of them
+		// was changed by another goroutine meanwhile, in which case start over.
+		if !pc.commit() {
+			continue
+		}
+
+		res := doc.(*Dict)
+		if res == root {
+			d.keys, d.values = res.keys, pc.restoreValues(res.keys, res.values)
+		} else {
+			// The root was replaced by a new dict, which might use another Hasher. Re-key
+			// its items with the Hasher of d.
+			res.mu.RLock()
+			values := pc.restoreValues(res.keys, res.values)
+			d.keys = make([]Key, 0, len(res.keys))
+			d.values = make(map[uint64]interface{}, len(res.keys))
+			for _, key := range res.keys {
+				k, _ := d.makeKey(key.Name)
+				d.keys = append(d.keys, k)
+				d.values[k.ID] = values[key.ID]
+			}
+			res.mu.RUnlock()
+		}
+		atomic.StoreInt64(&d.size, int64(len(d.keys)))
+		atomic.AddInt64(&d.version, 1)
+
+		return nil
+	}
+}
+
+// patchCopy is a copy of a dict tree for ApplyPatch. Each embedded dict is copied once,
+// so a dict found under many keys is still one dict in the copy.
+type patchCopy struct {
+	root     *Dict
+	copies   map[*Dict]*Dict // original -> copy
+	versions map[*Dict]int   // version of the original when copied
+}
+
+// dict returns the copy of d, making it if needed. The root is already locked.
+func (pc *patchCopy) dict(d *Dict) *Dict {
+	if c, ok := pc.copies[d]; ok {
+		return c
+	}
+
+	if d != pc.root {
+		d.mu.RLock()
+	}
+	c := d.copyItems(nil)
+	pc.versions[d] = d.Version()
+	if d != pc.root {
+		d.mu.RUnlock()
+	}
+
+	pc.copies[d] = c
+	for id, v := range c.values {
+		c.values[id] = pc.value(v)
+	}
+	return c
+}
+
+// value returns a copy of v, with the embedded dicts replaced by their copies. Only the
+// values that the patch operations change in place are copied: dicts and JSON objects.
+func (pc *patchCopy) value(v interface{}) interface{} {
+	switch x := v.(type) {
+	case *Dict:
+		if x == nil {
+			return x
+		}
+		return pc.dict(x)
+	case map[string]interface{}:
+		if x == nil {
+			return x
+		}
+		m := make(map[string]interface{}, len(x))
+		for k, v := range x {
+			m[k] = pc.value(v)
+		}
+		return m
+	case []interface{}:
+		if x == nil {
+			return x
+		}
+		s := make([]interface{}, len(x))
+		for i := range x {
+			s[i] = pc.value(x[i])
+		}
+		return s
+	}
+	return convertDicts(v, dictType, dictType, func(v interface{}) interface{} {
+		return pc.dict(v.(*Dict))
+	})
+}
+
+// restoreValues returns values with the dict copies replaced by their originals.
+func (pc *patchCopy) restoreValues(keys []Key, values map[uint64]interface{}) map[uint64]interface{} {
+	originals := make(map[*Dict]*Dict, len(pc.copies))
+	for d, c := range pc.copies {
+		originals[c] = d
+	}
+	res := make(map[uint64]interface{}, len(keys))
+	for _, key := range keys {
+		res[key.ID] = convertDicts(values[key.ID], dictType, dictType, func(v interface{}) interface{} {
+			if d, ok := originals[v.(*Dict)]; ok {
+				return d
+			}
+			return v
+		})
+	}
+	return res
+}
+
+// commit writes the changed copies of embedded dicts to the originals, all at once. The
+// root is written by ApplyPatch.
+// Returns false if nothing was written because an original was changed after it was
+// copied.
+func (pc *patchCopy) commit() bool {
+	var changed []*Dict
+	for d, c := range pc.copies {
+		if d != pc.root && c.Version() != 0 {
+			changed = append(changed, d)
+		}
+	}
+	if len(changed) == 0 {
+		return true
+	}
+
+	// Lock in address order, so concurrent patches can't deadlock.
+	sort.Slice(changed, func(i, j int) bool {
+		return reflect.ValueOf(changed[i]).Pointer() < reflect.ValueOf(changed[j]).Pointer()
+	})
+	for _, d := range changed {
+		d.mu.Lock()
+		defer d.mu.Unlock()
+	}
+	for _, d := range changed {
+		if d.Version() != pc.versions[d] {
+			return false
+		}
+	}
+
+	for _, d := range changed {
+		c := pc.copies[d]
+		d.keys, d.values = c.keys, pc.restoreValues(c.keys, c.values)
+		atomic.StoreInt64(&d.size, int64(len(d.keys)))
+		atomic.AddInt64(&d.version, 1)
+	}
+	return true
+}
+
+// applyPatch applies the operations of patch to doc.
+// Returns the new document root, which must be a dict.
+func applyPatch(doc interface{}, patch Patch) (interface{}, error) {
+	for i := range patch {
+		var err error
+		doc, err = applyOperation(doc, &patch[i])
+		if err != nil {
+			return nil, fmt.Errorf("operation %d (%s %q): %w", i, patch[i].Op, patch[i].Path, err)
+		}
+	}
+	if _, ok := doc.(*Dict); !ok {
+		return nil, fmt.Errorf("%w: document root must be a dict", ErrInvalidPatch)
+	}
+	return doc, nil
+}
+
+// CreatePatch generates a patch that transforms dict a into dict b. Keys are visited in the
+// insertion order of a, followed by the keys added in b in their insertion order, so the
+// same pair of dicts always produces the same patch. Embedded dicts are compared
+// recursively; any other changed value is replaced whole. Key order is not part of a
+// JSON object, so reordered keys don't produce operations.
+func CreatePatch(a, b *Dict) Patch {
+	var patch Patch
+	for _, c := range Diff(a, b) {
+		switch c.Kind {
+		case ChangeAdded:
+			patch = append(patch, Operation{Op: "add", Path: c.Path, Value: c.New})
+		case ChangeRemoved:
+			patch = append(patch, Operation{Op: "remove", Path: c.Path})
+		case ChangeModified:
+			patch = append(patch, Operation{Op: "replace", Path: c.Path, Value: c.New})
+		}
+	}
+	return patch
+}
+
+func applyOperation(doc interface{}, op *Operation) (interface{}, error) {
+	path, err := parsePointer(op.Path)
+	if err != nil {
+		return nil, err
+	}
+
+	switch op.Op {
+	case "add":
+		return addValue(doc, path, deepCopyValue(op.Value))
+
+	case "remove":
+		if len(path) == 0 {
+			return nil, fmt.Errorf("%w: cannot remove the document root", ErrInvalidPath)
+		}
+		return walkPointer(doc, path, removeChild)
+
+	case "replace":
+		if len(path) == 0 {
+			return deepCopyValue(op.Value), nil
+		}
+		if _, err := getPointer(doc, path); err != nil {
+			return nil, err
+		}
+		return walkPointer(doc, path, func(c interface{}, tok string) (interface{}, error) {
+			return setChild(c, tok, deepCopyValue(op.Value))
+		})
+
+	case "move":
+		from, err := parsePointer(op.From)
+		if err != nil {
+			return nil, err
+		}
+		if op.Path == op.From {
+			_, err = getPointer(doc, from)
+			return doc, err
+		}
+		if strings.HasPrefix(op.Path, op.From+"/") {
+			return nil, fmt.Errorf("%w: cannot move %q into itself", ErrInvalidPath, op.From)
+		}
+		value, err := getPointer(doc, from)
+		if err != nil {
+			return nil, err
+		}
+		if len(from) == 0 {
+			return nil, fmt.Errorf("%w: cannot move the document root", ErrInvalidPath)
+		}
+		doc, err = walkPointer(doc, from, removeChild)
+		if err != nil {
+			return nil, err
+		}
+		return addValue(doc, path, value)
+
+	case "copy":
+		from, err := parsePointer(op.From)
+		if err != nil {
+			return nil, err
+		}
+		value, err := getPointer(doc, from)
+		if err != nil {
+			return nil, err
+		}
+		return addValue(doc, path, deepCopyValue(value))
+
+	case "test":
+		value, err := getPointer(doc, path)
+		if err != nil {
+			return nil, err
+		}
+		if !valuesEqual(value, op.Value) {
+			return nil, ErrTestFailed
+		}
+		return doc, nil
+	}
+
+	return nil, fmt.Errorf("%w: unknown operation %q", ErrInvalidPatch, op.Op)
+}
+
+func addValue(doc interface{}, path []string, value interface{}) (interface{}, error) {
+	if len(path) == 0 {
+		return value, nil
+	}
+	return walkPointer(doc, path, func(c interface{}, tok string) (interface{}, error) {
+		return addChild(c, tok, value)
+	})
+}
+
+// parsePointer splits a JSON Pointer into its unescaped reference tokens.
+func parsePointer(s string) ([]string, error) {
+	if s == "" {
+		return nil, nil
+	}
+	if s[0] != '/' {
+		return nil, fmt.Errorf("%w: %q", ErrInvalidPath, s)
+	}
+	tokens := strings.Split(s[1:], "/")
+	for i := range tokens {
+		tokens[i] = strings.NewReplacer("~1", "/", "~0", "~").Replace(tokens[i])
+	}
+	return tokens, nil
+}
+
+func escapePointer(s string) string {
+	return strings.NewReplacer("~", "~0", "/", "~1").Replace(s)
+}
+
+// walkPointer descends doc along path and calls fn with the parent container of the last
+// token. Slices are immutable here, so each container on the way is stored back into its
+// parent in case fn returned a new one.
+func walkPointer(doc interface{}, path []string, fn func(interface{}, string) (interface{}, error)) (interface{}, error) {
+	if len(path) == 1 {
+		return fn(doc, path[0])
+	}
+	child, err := getChild(doc, path[0])
+	if err != nil {
+		return nil, err
+	}
+	child, err = walkPointer(child, path[1:], fn)
+	if err != nil {
+		return nil, err
+	}
+	return setChild(doc, path[0], child)
+}
+
+func getPointer(doc interface{}, path []string) (interface{}, error) {
+	var err error
+	for _, tok := range path {
+		if doc, err = getChild(doc, tok); err != nil {
+			return nil, err
+		}
+	}
+	return doc, nil
+}
+
+func getChild(c interface{}, tok string) (interface{}, error) {
+	if d, ok := c.(*Dict); ok {
+		value, ok := d.lookup(tok)
+		if !ok {
+			return nil, fmt.Errorf("%w: %q", ErrPathNotFound, tok)
+		}
+		return value, nil
+	}
+	if m, ok := c.(map[string]interface{}); ok {
+		value, ok := m[tok]
+		if !ok {
+			return nil, fmt.Errorf("%w: %q", ErrPathNotFound, tok)
+		}
+		return value, nil
+	}
+	v := reflect.ValueOf(c)
+	if v.Kind() != reflect.Slice && v.Kind() != reflect.Array {
+		return nil, fmt.Errorf("%w: %q", ErrPathNotFound, tok)
+	}
+	idx, err := parseIndex(tok, v.Len()-1)
+	if err != nil {
+		return nil, err
+	}
+	return v.Index(idx).Interface(), nil
+}
+
+func setChild(c interface{}, tok string, value interface{}) (interface{}, error) {
+	if d, ok := c.(*Dict); ok {
+		if MakeKey(tok) == nil {
+			return nil, fmt.Errorf("%w: invalid key %q", ErrInvalidPath, tok)
+		}
+		d.Set(tok, value)
+		return d, nil
+	}
+	if m, ok := c.(map[string]interface{}); ok {
+		m[tok] = value
+		return m, nil
+	}
+	v := reflect.ValueOf(c)
+	if v.Kind() != reflect.Slice && v.Kind() != reflect.Array {
+		return nil, fmt.Errorf("%w: %q", ErrPathNotFound, tok)
+	}
+	idx, err := parseIndex(tok, v.Len()-1)
+	if err != nil {
+		return nil, err
+	}
+	s := copySlice(v, value, 0)
+	s.Index(idx).Set(reflectValue(value, s.Type().Elem()))
+	return s.Interface(), nil
+}
+
+func addChild(c interface{}, tok string, value interface{}) (interface{}, error) {
+	switch c.(type) {
+	case *Dict, map[string]interface{}:
+		return setChild(c, tok, value)
+	}
+	v := reflect.ValueOf(c)
+	if v.Kind() != reflect.Slice && v.Kind() != reflect.Array {
+		return nil, fmt.Errorf("%w: %q", ErrPathNotFound, tok)
+	}
+	idx := v.Len()
+	if tok != "-" {
+		var err error
+		if idx, err = parseIndex(tok, v.Len()); err != nil {
+			return nil, err
+		}
+	}
+	s := copySlice(v, value, 1)
+	reflect.Copy(s.Slice(idx+1, s.Len()), s.Slice(idx, s.Len()-1))
+	s.Index(idx).Set(reflectValue(value, s.Type().Elem()))
+	return s.Interface(), nil
+}
+
+func removeChild(c interface{}, tok string) (interface{}, error) {
+	if d, ok := c.(*Dict); ok {
+		if !d.Del(tok) {
+			return nil, fmt.Errorf("%w: %q", ErrPathNotFound, tok)
+		}
+		return d, nil
+	}
+	if m, ok := c.(map[string]interface{}); ok {
+		if _, ok := m[tok]; !ok {
+			return nil, fmt.Errorf("%w: %q", ErrPathNotFound, tok)
+		}
+		delete(m, tok)
+		return m, nil
+	}
+	v := reflect.ValueOf(c)
+	if v.Kind() != reflect.Slice && v.Kind() != reflect.Array {
+		return nil, fmt.Errorf("%w: %q", ErrPathNotFound, tok)
+	}
+	idx, err := parseIndex(tok, v.Len()-1)
+	if err != nil {
+		return nil, err
+	}
+	s := reflect.MakeSlice(reflect.SliceOf(v.Type().Elem()), 0, v.Len()-1)
+	for i := 0; i < v.Len(); i++ {
+		if i != idx {
+			s = reflect.Append(s, v.Index(i))
+		}
+	}
+	return s.Interface(), nil
+}
+
+// parseIndex parses an array index token, which must be in the range [0, max].
+func parseIndex(tok string, max int) (int, error) {
+	idx, err := strconv.Atoi(tok)
+	if err != nil || idx < 0 || (len(tok) > 1 && tok[0] == '0') {
+		return 0, fmt.Errorf("%w: invalid array index %q", ErrInvalidPath, tok)
+	}
+	if idx > max {
+		return 0, fmt.Errorf("%w: array index %q out of range", ErrPathNotFound, tok)
+	}
+	return idx, nil
+}
+
+// copySlice returns a copy of the slice or array v with extra zero elements at the end.
+// If value can't be stored in the element type of v, the copy is a []interface{}.
+func copySlice(v reflect.Value, value interface{}, extra int) reflect.Value {
+	t := reflect.SliceOf(v.Type().Elem())
+	if value == nil || !reflect.TypeOf(value).AssignableTo(t.Elem()) {
+		if !(value == nil && isNillable(t.Elem().Kind())) {
+			t = reflect.TypeOf([]interface{}{})
+		}
+	}
+	s := reflect.MakeSlice(t, v.Len()+extra, v.Len()+extra)
+	for i := 0; i < v.Len(); i++ {
+		s.Index(i).Set(v.Index(i))
+	}
+	return s
+}
+
+func reflectValue(value interface{}, t reflect.Type) reflect.Value {
+	if value == nil {
+		return reflect.Zero(t)
+	}
+	return reflect.ValueOf(value)
+}
+
+func isNillable(k reflect.Kind) bool {
+	switch k {
+	case reflect.Interface, reflect.Ptr, reflect.Slice, reflect.Map, reflect.Chan, reflect.Func:
+		return true
+	}
+	return false
+}
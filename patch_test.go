@@ -0,0 +1,218 @@
+// Copyright (c) 2025 srfrog - https://srfrog.dev
+// Use of this source code is governed by the license in the LICENSE file.
+
+package dict
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func newPatchDoc(t *testing.T) *Dict {
+	return New().
+		Set("name", "gopher").
+		Set("tags", []string{"go", "dict"}).
+		Set("owner", New().Set("id", 7).Set("email", "gopher@example.com")).
+		Set("a/b", 1).
+		Set("m~n", 2)
+}
+
+func TestApplyPatch(t *testing.T) {
+	tests := []struct {
+		name  string
+		patch string
+		out   string
+	}{
+		{"add key", `[{"op": "add", "path": "/age", "value": 12}]`,
+			`{"name":"gopher","tags":["go","dict"],"owner":{"id":7,"email":"gopher@example.com"},"a/b":1,"m~n":2,"age":12}`},
+		{"add object", `[{"op": "add", "path": "/owner/team", "value": {"lead": true}}]`,
+			`{"name":"gopher","tags":["go","dict"],"owner":{"id":7,"email":"gopher@example.com","team":{"lead":true}},"a/b":1,"m~n":2}`},
+		{"add array index", `[{"op": "add", "path": "/tags/1", "value": "json"}]`,
+			`{"name":"gopher","tags":["go","json","dict"],"owner":{"id":7,"email":"gopher@example.com"},"a/b":1,"m~n":2}`},
+		{"add array end", `[{"op": "add", "path": "/tags/-", "value": 3}]`,
+			`{"name":"gopher","tags":["go","dict",3],"owner":{"id":7,"email":"gopher@example.com"},"a/b":1,"m~n":2}`},
+		{"remove key", `[{"op": "remove", "path": "/owner/email"}]`,
+			`{"name":"gopher","tags":["go","dict"],"owner":{"id":7},"a/b":1,"m~n":2}`},
+		{"remove array index", `[{"op": "remove", "path": "/tags/0"}]`,
+			`{"name":"gopher","tags":["dict"],"owner":{"id":7,"email":"gopher@example.com"},"a/b":1,"m~n":2}`},
+		{"replace escaped", `[{"op": "replace", "path": "/a~1b", "value": 10}, {"op": "replace", "path": "/m~0n", "value": 20}]`,
+			`{"name":"gopher","tags":["go","dict"],"owner":{"id":7,"email":"gopher@example.com"},"a/b":10,"m~n":20}`},
+		{"move", `[{"op": "move", "from": "/owner/id", "path": "/id"}]`,
+			`{"name":"gopher","tags":["go","dict"],"owner":{"email":"gopher@example.com"},"a/b":1,"m~n":2,"id":7}`},
+		{"copy", `[{"op": "copy", "from": "/tags", "path": "/owner/tags"}]`,
+			`{"name":"gopher","tags":["go","dict"],"owner":{"id":7,"email":"gopher@example.com","tags":["go","dict"]},"a/b":1,"m~n":2}`},
+		{"test", `[{"op": "test", "path": "/owner", "value": {"email": "gopher@example.com", "id": 7}}]`,
+			`{"name":"gopher","tags":["go","dict"],"owner":{"id":7,"email":"gopher@example.com"},"a/b":1,"m~n":2}`},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			d := newPatchDoc(t)
+			patch, err := DecodePatch([]byte(tc.patch))
+			require.NoError(t, err)
+
+			ver := d.Version()
+			require.NoError(t, d.ApplyPatch(patch))
+			require.Equal(t, ver+1, d.Version())
+
+			b, err := json.Marshal(d)
+			require.NoError(t, err)
+			require.JSONEq(t, tc.out, string(b))
+		})
+	}
+}
+
+func TestApplyPatchErr(t *testing.T) {
+	tests := []struct {
+		name  string
+		patch string
+		err   error
+	}{
+		{"test failed", `[{"op": "remove", "path": "/name"}, {"op": "test", "path": "/owner/id", "value": 8}]`, ErrTestFailed},
+		{"missing key", `[{"op": "add", "path": "/x", "value": 1}, {"op": "remove", "path": "/nope"}]`, ErrPathNotFound},
+		{"missing parent", `[{"op": "add", "path": "/nope/x", "value": 1}]`, ErrPathNotFound},
+		{"replace missing", `[{"op": "replace", "path": "/nope", "value": 1}]`, ErrPathNotFound},
+		{"array out of range", `[{"op": "add", "path": "/tags/5", "value": 1}]`, ErrPathNotFound},
+		{"bad index", `[{"op": "add", "path": "/tags/01", "value": 1}]`, ErrInvalidPath},
+		{"bad pointer", `[{"op": "add", "path": "x", "value": 1}]`, ErrInvalidPath},
+		{"move into child", `[{"op": "move", "from": "/owner", "path": "/owner/x"}]`, ErrInvalidPath},
+		{"unknown op", `[{"op": "frob", "path": "/name"}]`, ErrInvalidPatch},
+		{"root not dict", `[{"op": "replace", "path": "", "value": 1}]`, ErrInvalidPatch},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			d := newPatchDoc(t)
+			before, err := json.Marshal(d)
+			require.NoError(t, err)
+			ver := d.Version()
+
+			patch, err := DecodePatch([]byte(tc.patch))
+			require.NoError(t, err)
+			err = d.ApplyPatch(patch)
+			require.True(t, errors.Is(err, tc.err), "expected %v but got %v", tc.err, err)
+
+			// Nothing was changed.
+			after, err := json.Marshal(d)
+			require.NoError(t, err)
+			require.JSONEq(t, string(before), string(after))
+			require.Equal(t, ver, d.Version())
+		})
+	}
+}
+
+func TestApplyPatchNested(t *testing.T) {
+	d := New()
+	require.NoError(t, json.Unmarshal([]byte(`{"items": [{"name": "a"}, 1]}`), d))
+
+	patch, err := DecodePatch([]byte(`[{"op": "replace", "path": "/items/0/name", "value": "b"}]`))
+	require.NoError(t, err)
+	require.NoError(t, d.ApplyPatch(patch))
+
+	b, err := json.Marshal(d)
+	require.NoError(t, err)
+	require.JSONEq(t, `{"items": [{"name": "b"}, 1]}`, string(b))
+
+	// Plain maps can be patched too.
+	m := New().Set("m", map[string]interface{}{"k": 1, "x": 2})
+	patch, err = DecodePatch([]byte(`[
+		{"op": "replace", "path": "/m/k", "value": 2},
+		{"op": "add", "path": "/m/n", "value": 3},
+		{"op": "remove", "path": "/m/x"}
+	]`))
+	require.NoError(t, err)
+	require.NoError(t, m.ApplyPatch(patch))
+	require.Equal(t, map[string]interface{}{"k": float64(2), "n": float64(3)}, m.Get("m"))
+}
+
+func TestApplyPatchInPlace(t *testing.T) {
+	d := newPatchDoc(t)
+	owner := d.Get("owner").(*Dict)
+
+	patch, err := DecodePatch([]byte(`[{"op": "replace", "path": "/owner/id", "value": 8}]`))
+	require.NoError(t, err)
+	require.NoError(t, d.ApplyPatch(patch))
+	require.Equal(t, float64(8), owner.Get("id"))
+	require.True(t, owner == d.Get("owner"))
+
+	// A failed patch leaves the embedded dict untouched.
+	patch, err = DecodePatch([]byte(`[
+		{"op": "replace", "path": "/owner/id", "value": 9},
+		{"op": "remove", "path": "/missing"}
+	]`))
+	require.NoError(t, err)
+	require.Error(t, d.ApplyPatch(patch))
+	require.Equal(t, float64(8), owner.Get("id"))
+}
+
+func TestApplyPatchShared(t *testing.T) {
+	// The same dict under two keys is changed once, and a failed patch doesn't change it.
+	x := New().Set("k", 1).Set("j", 2)
+	d := New().Set("a", x).Set("b", x)
+
+	err := d.ApplyPatch(Patch{{Op: "remove", Path: "/a/k"}, {Op: "remove", Path: "/b/k"}})
+	require.True(t, errors.Is(err, ErrPathNotFound))
+	require.Equal(t, []string{"k", "j"}, x.Keys())
+
+	ver := x.Version()
+	require.NoError(t, d.ApplyPatch(Patch{{Op: "remove", Path: "/a/k"}, {Op: "add", Path: "/b/n", Value: 3}}))
+	require.Equal(t, []string{"j", "n"}, x.Keys())
+	require.Equal(t, ver+1, x.Version())
+	require.True(t, d.Get("a") == x && d.Get("b") == x)
+
+	// Moved dicts stay the same dict.
+	require.NoError(t, d.ApplyPatch(Patch{{Op: "move", From: "/a", Path: "/c"}}))
+	require.True(t, d.Get("c") == x)
+}
+
+func TestApplyPatchRoot(t *testing.T) {
+	h := NewSipHasher(1, 2)
+	d := NewWithHasher(h).Set("x", 1)
+	d.factory = func(string) interface{} { return 0 }
+
+	patch, err := DecodePatch([]byte(`[{"op": "replace", "path": "", "value": {"a": 1, "b": {"c": 2}}}]`))
+	require.NoError(t, err)
+	require.NoError(t, d.ApplyPatch(patch))
+	require.ElementsMatch(t, []string{"a", "b"}, d.Keys())
+	require.Equal(t, float64(1), d.Get("a"))
+	for _, key := range d.keys {
+		require.Equal(t, h([]byte(key.Name)), key.ID)
+	}
+	require.False(t, d.Key("x"))
+	require.Equal(t, 0, d.Get("missing"))
+}
+
+func TestDecodePatchErr(t *testing.T) {
+	_, err := DecodePatch([]byte(`{"op": "add"}`))
+	require.Error(t, err)
+	_, err = DecodePatch([]byte(`[{"op": "add", "value": 1}]`))
+	require.True(t, errors.Is(err, ErrInvalidPatch))
+}
+
+func TestCreatePatch(t *testing.T) {
+	a := newPatchDoc(t)
+	b := newPatchDoc(t)
+	b.Del("name")
+	b.Set("tags", []string{"go"})
+	b.Get("owner").(*Dict).Set("id", float64(8)).Set("x/y", true)
+	b.Set("new", "value")
+
+	patch := CreatePatch(a, b)
+	p, err := json.Marshal(patch)
+	require.NoError(t, err)
+	require.JSONEq(t, `[
+		{"op": "remove", "path": "/name"},
+		{"op": "replace", "path": "/tags", "value": ["go"]},
+		{"op": "replace", "path": "/owner/id", "value": 8},
+		{"op": "add", "path": "/owner/x~1y", "value": true},
+		{"op": "add", "path": "/new", "value": "value"}
+	]`, string(p))
+
+	// Round trip through JSON and apply.
+	patch, err = DecodePatch(p)
+	require.NoError(t, err)
+	require.NoError(t, a.ApplyPatch(patch))
+	require.Empty(t, CreatePatch(a, b))
+	require.Empty(t, CreatePatch(b, b))
+}
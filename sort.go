@@ -0,0 +1,172 @@
+// Copyright (c) 2025 srfrog - https://srfrog.dev
+// Use of this source code is governed by the license in the LICENSE file.
+
+package dict
+
+import (
+	"fmt"
+	"math"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"sync/atomic"
+)
+
+// SortKind selects the part of an item used for sorting.
+type SortKind int
+
+// Sort kinds.
+const (
+	ByKey SortKind = iota
+	ByValue
+)
+
+// SortOrder is the direction of a sort.
+type SortOrder int
+
+// Sort orders.
+const (
+	Ascending SortOrder = iota
+	Descending
+)
+
+// CompareKeys compares two keys in natural order: keys with numeric names are sorted by
+// their numeric value and before all other keys, which are sorted by name. So 9 is sorted
+// before 10, unlike their names "9" and "10".
+// Returns -1 if a is less than b, 1 if a is greater than b, and 0 if they are equal.
+func CompareKeys(a, b *Key) int {
+	an, aok := keyNumber(a.Name)
+	bn, bok := keyNumber(b.Name)
+	switch {
+	case aok && bok:
+		if an != bn {
+			if an < bn {
+				return -1
+			}
+			return 1
+		}
+	case aok:
+		return -1
+	case bok:
+		return 1
+	}
+	return strings.Compare(a.Name, b.Name)
+}
+
+// keyNumber returns the numeric value of a key name and true, or false if the name is not
+// a finite decimal number.
+func keyNumber(name string) (float64, bool) {
+	if name == "" || !(name[0] == '-' || name[0] == '.' || (name[0] >= '0' && name[0] <= '9')) {
+		return 0, false
+	}
+	f, err := strconv.ParseFloat(name, 64)
+	if err != nil || math.IsInf(f, 0) {
+		return 0, false
+	}
+	return f, true
+}
+
+// SortKeys sorts the items of d in place by key, using cmp to compare the keys. If cmp is
+// nil, CompareKeys is used to sort keys in natural order. The sort is stable.
+func (d *Dict) SortKeys(cmp func(a, b *Key) int) {
+	if d.IsEmpty() {
+		return
+	}
+	if cmp == nil {
+		cmp = CompareKeys
+	}
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	sort.SliceStable(d.keys, func(i, j int) bool {
+		return cmp(&d.keys[i], &d.keys[j]) < 0
+	})
+	atomic.AddInt64(&d.version, 1)
+}
+
+// SortedItems returns the items of d sorted by key or by value, in ascending or descending
+// order, without changing d. Keys are sorted in natural order, see CompareKeys. Values are
+// sorted by type and then by value: nil first, then numbers, strings, and any other value
+// by its formatted string. The sort is stable, so items with equal keys or values keep
+// their order.
+// Returns the sorted items, or nil if d is empty.
+func (d *Dict) SortedItems(by SortKind, order SortOrder) []Item {
+	if d.IsEmpty() {
+		return nil
+	}
+
+	d.mu.RLock()
+	keys := append([]Key(nil), d.keys...)
+	items := make([]Item, len(keys))
+	for i, key := range keys {
+		items[i] = Item{Key: key.Name, Value: d.values[key.ID]}
+	}
+	d.mu.RUnlock()
+
+	sort.Stable(&itemSorter{keys: keys, items: items, less: func(i, j int) bool {
+		if order == Descending {
+			i, j = j, i
+		}
+		if by == ByValue {
+			return compareValues(items[i].Value, items[j].Value) < 0
+		}
+		return CompareKeys(&keys[i], &keys[j]) < 0
+	}})
+
+	return items
+}
+
+// compareValues compares dict values for sorting.
+// Returns -1 if a is less than b, 1 if a is greater than b, and 0 if they are equal.
+func compareValues(a, b interface{}) int {
+	ar, br := valueRank(a), valueRank(b)
+	if ar != br {
+		if ar < br {
+			return -1
+		}
+		return 1
+	}
+
+	switch ar {
+	case 0:
+		return 0
+	case 1:
+		af, bf := toNumber(reflect.ValueOf(a)), toNumber(reflect.ValueOf(b))
+		switch {
+		case af < bf:
+			return -1
+		case af > bf:
+			return 1
+		}
+		return 0
+	case 2:
+		return strings.Compare(reflect.ValueOf(a).String(), reflect.ValueOf(b).String())
+	}
+	return strings.Compare(fmt.Sprint(a), fmt.Sprint(b))
+}
+
+// valueRank returns the sort group of a value: nil, numbers, strings and others.
+func valueRank(v interface{}) int {
+	rv := reflect.ValueOf(v)
+	switch {
+	case !rv.IsValid():
+		return 0
+	case isNumber(rv):
+		return 1
+	case rv.Kind() == reflect.String && !rv.Type().Implements(stringerType):
+		return 2
+	}
+	return 3
+}
+
+func toNumber(v reflect.Value) float64 {
+	switch kind := v.Kind(); {
+	case kind >= reflect.Int && kind <= reflect.Int64:
+		return float64(v.Int())
+	case kind >= reflect.Uint && kind <= reflect.Uint64:
+		return float64(v.Uint())
+	}
+	return v.Float()
+}
@@ -0,0 +1,89 @@
+// Copyright (c) 2025 srfrog - https://srfrog.dev
+// Use of this source code is governed by the license in the LICENSE file.
+
+package dict
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCompareKeys(t *testing.T) {
+	tests := []struct {
+		a, b interface{}
+		out  int
+	}{
+		{a: 9, b: 10, out: -1},
+		{a: 10, b: 9, out: 1},
+		{a: 1.5, b: uint8(2), out: -1},
+		{a: -1, b: 0, out: -1},
+		{a: 3, b: 3.0, out: 0},
+		{a: 100, b: "a", out: -1},
+		{a: "9", b: "10", out: -1},
+		{a: "9", b: 10, out: -1},
+		{a: "-1.5", b: "x", out: -1},
+		{a: "1e3", b: 999, out: 1},
+		{a: "Inf", b: 1, out: 1},
+		{a: "a", b: "b", out: -1},
+		{a: testDevice(0x2), b: testDevice(0x10), out: 1},
+	}
+	for _, tc := range tests {
+		require.Equal(t, tc.out, CompareKeys(MakeKey(tc.a), MakeKey(tc.b)), "%v <=> %v", tc.a, tc.b)
+	}
+
+	// Keys made with positional literals sort the same as keys from MakeKey.
+	a, b := MakeKey(9), MakeKey(10)
+	require.Equal(t, -1, CompareKeys(&Key{a.ID, a.Name}, &Key{b.ID, b.Name}))
+}
+
+func TestSortKeys(t *testing.T) {
+	d := New().Set(10, "ten").Set("b", "bee").Set(9, "nine").Set("a", "ay").Set(0.5, "half")
+
+	d.SortKeys(nil)
+	require.Equal(t, []string{"0.5", "9", "10", "a", "b"}, d.Keys())
+	require.Equal(t, "nine", d.Get(9))
+
+	d.SortKeys(func(a, b *Key) int { return -strings.Compare(a.Name, b.Name) })
+	require.Equal(t, []string{"b", "a", "9", "10", "0.5"}, d.Keys())
+
+	New().SortKeys(nil)
+}
+
+func TestSortedItems(t *testing.T) {
+	d := New().Set(10, 3).Set(9, "x").Set(2, nil).Set(1, 1.5).Set(0, "a")
+
+	tests := []struct {
+		by    SortKind
+		order SortOrder
+		keys  []interface{}
+	}{
+		{by: ByKey, order: Ascending, keys: []interface{}{"0", "1", "2", "9", "10"}},
+		{by: ByKey, order: Descending, keys: []interface{}{"10", "9", "2", "1", "0"}},
+		{by: ByValue, order: Ascending, keys: []interface{}{"2", "1", "10", "0", "9"}},
+		{by: ByValue, order: Descending, keys: []interface{}{"9", "0", "10", "1", "2"}},
+	}
+	for _, tc := range tests {
+		var keys []interface{}
+		for _, item := range d.SortedItems(tc.by, tc.order) {
+			require.Equal(t, d.Get(item.Key), item.Value)
+			keys = append(keys, item.Key)
+		}
+		require.Equal(t, tc.keys, keys)
+	}
+
+	// The dict is not changed.
+	require.Equal(t, []string{"10", "9", "2", "1", "0"}, d.Keys())
+	require.Nil(t, New().SortedItems(ByKey, Ascending))
+}
+
+func TestSortedItemsStable(t *testing.T) {
+	d := New().Set("a", 1).Set("b", 0).Set("c", 1).Set("d", 0)
+
+	var keys []interface{}
+	for _, item := range d.SortedItems(ByValue, Ascending) {
+		keys = append(keys, item.Key)
+	}
+	require.Equal(t, []interface{}{"b", "d", "a", "c"}, keys)
+}
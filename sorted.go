@@ -0,0 +1,571 @@
+// Copyright (c) 2025 srfrog - https://srfrog.dev
+// Use of this source code is governed by the license in the LICENSE file.
+
+package dict
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/rand"
+	"reflect"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+const (
+	// sortedMaxLevel is enough for 4^32 items with sortedP.
+	sortedMaxLevel = 32
+	sortedP        = 4
+)
+
+// SortedDict is a dict that keeps its keys sorted by a comparison function at all times,
+// instead of insertion order. It's backed by an indexable skip list, so lookups by key
+// are O(1) and ordered operations, including positional access, are O(log n).
+type SortedDict struct {
+	size, version int64
+	cmp           func(a, b *Key) int
+	head          *sortedNode
+	level         int
+	nodes         map[uint64]*sortedNode
+	rnd           *rand.Rand
+	mu            sync.RWMutex
+}
+
+// sortedNode is a skip list node. span[i] is the number of nodes between the node and
+// next[i], used to find positions.
+type sortedNode struct {
+	key   *Key
+	value interface{}
+	next  []*sortedNode
+	span  []int
+}
+
+// NewSorted returns a new SortedDict object that sorts keys with cmp. If cmp is nil, keys
+// are sorted in natural order, see CompareKeys.
+// vargs are the initial values, as in New().
+func NewSorted(cmp func(a, b *Key) int, vargs ...interface{}) *SortedDict {
+	if cmp == nil {
+		cmp = CompareKeys
+	}
+	d := &SortedDict{cmp: cmp}
+	d.init()
+	d.Update(vargs...)
+	return d
+}
+
+// init resets d to an empty skip list. The caller must hold the lock.
+func (d *SortedDict) init() {
+	if d.cmp == nil {
+		d.cmp = CompareKeys
+	}
+	if d.rnd == nil {
+		d.rnd = rand.New(rand.NewSource(time.Now().UnixNano()))
+	}
+	d.head = &sortedNode{
+		next: make([]*sortedNode, sortedMaxLevel),
+		span: make([]int, sortedMaxLevel),
+	}
+	d.level = 1
+	d.nodes = make(map[uint64]*sortedNode)
+}
+
+// compare orders keys with the dict cmp, breaking ties by key name and ID so that the
+// order of the skip list is always total.
+func (d *SortedDict) compare(a, b *Key) int {
+	if c := d.cmp(a, b); c != 0 {
+		return c
+	}
+	if c := strings.Compare(a.Name, b.Name); c != 0 {
+		return c
+	}
+	switch {
+	case a.ID < b.ID:
+		return -1
+	case a.ID > b.ID:
+		return 1
+	}
+	return 0
+}
+
+func (d *SortedDict) randomLevel() int {
+	level := 1
+	for level < sortedMaxLevel && d.rnd.Intn(sortedP) == 0 {
+		level++
+	}
+	return level
+}
+
+// Version returns the version of the dictionary. The version is increased after every
+// change to dict items.
+// Returns version, which is zero (0) initially.
+func (d *SortedDict) Version() int {
+	return int(atomic.LoadInt64(&d.version))
+}
+
+// Len returns the size of a SortedDict.
+func (d *SortedDict) Len() int {
+	return int(atomic.LoadInt64(&d.size))
+}
+
+// IsEmpty returns true if the dict is empty, false otherwise.
+func (d *SortedDict) IsEmpty() bool {
+	return d == nil || d.Len() == 0
+}
+
+// Set inserts a new item into the dict at its sorted position. If a value matching the
+// key already exists, its value is replaced, otherwise a new item is added.
+func (d *SortedDict) Set(key, value interface{}) *SortedDict {
+	// Sanity: don't panic on nil dict, just create a new one.
+	if d == nil {
+		d = NewSorted(nil)
+	}
+
+	k := MakeKey(key)
+	if k == nil {
+		return d
+	}
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if d.head == nil {
+		d.init()
+	}
+
+	if x, ok := d.nodes[k.ID]; ok {
+		curr := x.value
+		x.value = value
+
+		// Value changed, update version.
+		if !reflect.DeepEqual(value, curr) {
+			atomic.AddInt64(&d.version, 1)
+		}
+
+		return d
+	}
+
+	var (
+		update [sortedMaxLevel]*sortedNode
+		rank   [sortedMaxLevel]int
+	)
+	x := d.head
+	for i := d.level - 1; i >= 0; i-- {
+		if i < d.level-1 {
+			rank[i] = rank[i+1]
+		}
+		for x.next[i] != nil && d.compare(x.next[i].key, k) < 0 {
+			rank[i] += x.span[i]
+			x = x.next[i]
+		}
+		update[i] = x
+	}
+
+	level := d.randomLevel()
+	if level > d.level {
+		for i := d.level; i < level; i++ {
+			update[i] = d.head
+			update[i].span[i] = d.Len()
+		}
+		d.level = level
+	}
+
+	x = &sortedNode{
+		key:   k,
+		value: value,
+		next:  make([]*sortedNode, level),
+		span:  make([]int, level),
+	}
+	for i := 0; i < level; i++ {
+		x.next[i] = update[i].next[i]
+		update[i].next[i] = x
+		x.span[i] = update[i].span[i] - (rank[0] - rank[i])
+		update[i].span[i] = rank[0] - rank[i] + 1
+	}
+	for i := level; i < d.level; i++ {
+		update[i].span[i]++
+	}
+
+	d.nodes[k.ID] = x
+	atomic.AddInt64(&d.size, 1)
+	atomic.AddInt64(&d.version, 1)
+
+	return d
+}
+
+// Get retrieves an item from dict by key. If alt value is passed, it will be used as
+// default value if no item is found.
+// Returns a value matching key in dict, otherwise nil or alt if given.
+func (d *SortedDict) Get(key interface{}, alt ...interface{}) interface{} {
+	if k := MakeKey(key); k != nil && !d.IsEmpty() {
+		d.mu.RLock()
+		defer d.mu.RUnlock()
+
+		if x, ok := d.nodes[k.ID]; ok {
+			return x.value
+		}
+	}
+	if alt != nil {
+		return alt[0]
+	}
+	return nil
+}
+
+// Key returns true if key is in dict d, false otherwise.
+func (d *SortedDict) Key(key interface{}) bool {
+	k := MakeKey(key)
+	if k == nil || d.IsEmpty() {
+		return false
+	}
+
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+
+	_, ok := d.nodes[k.ID]
+	return ok
+}
+
+// Del removes an item from dict by key name.
+// Returns true if an item is found and removed, false otherwise.
+func (d *SortedDict) Del(key interface{}) bool {
+	k := MakeKey(key)
+	if k == nil || d.IsEmpty() {
+		return false
+	}
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	x, ok := d.nodes[k.ID]
+	if !ok {
+		return false
+	}
+	d.deleteNode(x)
+
+	return true
+}
+
+// deleteNode unlinks node n from the skip list. The caller must hold the lock.
+func (d *SortedDict) deleteNode(n *sortedNode) {
+	var update [sortedMaxLevel]*sortedNode
+	x := d.head
+	for i := d.level - 1; i >= 0; i-- {
+		for x.next[i] != nil && d.compare(x.next[i].key, n.key) < 0 {
+			x = x.next[i]
+		}
+		update[i] = x
+	}
+
+	for i := 0; i < d.level; i++ {
+		if update[i].next[i] == n {
+			update[i].span[i] += n.span[i] - 1
+			update[i].next[i] = n.next[i]
+		} else {
+			update[i].span[i]--
+		}
+	}
+	for d.level > 1 && d.head.next[d.level-1] == nil {
+		d.level--
+	}
+
+	delete(d.nodes, n.key.ID)
+	atomic.AddInt64(&d.size, -1)
+	atomic.AddInt64(&d.version, 1)
+}
+
+// Clear empties a SortedDict d.
+// Returns true if the dict was actually cleared, otherwise false if nothing was done.
+func (d *SortedDict) Clear() bool {
+	if d.IsEmpty() {
+		return false
+	}
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	d.init()
+	atomic.StoreInt64(&d.size, 0)
+	atomic.AddInt64(&d.version, 1)
+
+	return true
+}
+
+// Keys returns a string slice of all dict keys in sorted order, or nil if dict is empty.
+func (d *SortedDict) Keys() []string {
+	if d.IsEmpty() {
+		return nil
+	}
+
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+
+	keys := make([]string, 0, d.Len())
+	for x := d.head.next[0]; x != nil; x = x.next[0] {
+		keys = append(keys, x.key.Name)
+	}
+	return keys
+}
+
+// Values returns a slice of all dict values in sorted key order, or nil if dict is empty.
+func (d *SortedDict) Values() []interface{} {
+	if d.IsEmpty() {
+		return nil
+	}
+
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+
+	values := make([]interface{}, 0, d.Len())
+	for x := d.head.next[0]; x != nil; x = x.next[0] {
+		values = append(values, x.value)
+	}
+	return values
+}
+
+// Items returns a channel of key-value items in sorted key order.
+func (d *SortedDict) Items() <-chan Item {
+	ci := make(chan Item)
+	if d.IsEmpty() {
+		close(ci)
+		return ci
+	}
+
+	// Avoid lock contention
+	items := d.Range(nil, nil)
+
+	go func() {
+		defer close(ci)
+		for _, item := range items {
+			ci <- item
+		}
+	}()
+
+	return ci
+}
+
+// Update adds to d the key-value items from iterables, scalars and other dicts, as in
+// Dict.Update().
+// Returns true if any changes were made.
+func (d *SortedDict) Update(vargs ...interface{}) bool {
+	if vargs == nil {
+		return false
+	}
+	ver := d.Version()
+	for i := range vargs {
+		// other dict
+		if other, ok := vargs[i].(itemsSource); ok {
+			for item := range other.Items() {
+				d.Set(item.Key, item.Value)
+			}
+			continue
+		}
+		// iterables and scalars
+		for item := range toIterable(vargs[i]) {
+			if item.Key == nil {
+				item.Key = d.Len()
+			}
+			d.Set(item.Key, item.Value)
+		}
+	}
+	return ver != d.Version()
+}
+
+// First returns the item with the lowest key, or nil if the dict is empty.
+func (d *SortedDict) First() *Item {
+	return d.Select(0)
+}
+
+// Last returns the item with the highest key, or nil if the dict is empty.
+func (d *SortedDict) Last() *Item {
+	return d.Select(d.Len() - 1)
+}
+
+// Floor returns the item with the greatest key less than or equal to key.
+// Returns the item, or nil if there is none.
+func (d *SortedDict) Floor(key interface{}) *Item {
+	k := MakeKey(key)
+	if k == nil || d.IsEmpty() {
+		return nil
+	}
+
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+
+	x := d.head
+	for i := d.level - 1; i >= 0; i-- {
+		for x.next[i] != nil && d.cmp(x.next[i].key, k) <= 0 {
+			x = x.next[i]
+		}
+	}
+	if x == d.head {
+		return nil
+	}
+	return x.item()
+}
+
+// Ceiling returns the item with the least key greater than or equal to key.
+// Returns the item, or nil if there is none.
+func (d *SortedDict) Ceiling(key interface{}) *Item {
+	k := MakeKey(key)
+	if k == nil || d.IsEmpty() {
+		return nil
+	}
+
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+
+	if x := d.ceiling(k); x != nil {
+		return x.item()
+	}
+	return nil
+}
+
+// ceiling returns the first node with a key greater than or equal to k, or nil.
+// The caller must hold the lock.
+func (d *SortedDict) ceiling(k *Key) *sortedNode {
+	x := d.head
+	for i := d.level - 1; i >= 0; i-- {
+		for x.next[i] != nil && d.cmp(x.next[i].key, k) < 0 {
+			x = x.next[i]
+		}
+	}
+	return x.next[0]
+}
+
+// Range returns the items with keys greater than or equal to lo and less than hi, in
+// sorted order. A nil lo starts at the first item and a nil hi ends at the last item.
+// Returns the items in range, or nil if there are none.
+func (d *SortedDict) Range(lo, hi interface{}) []Item {
+	if d.IsEmpty() {
+		return nil
+	}
+
+	var klo, khi *Key
+	if lo != nil {
+		if klo = MakeKey(lo); klo == nil {
+			return nil
+		}
+	}
+	if hi != nil {
+		if khi = MakeKey(hi); khi == nil {
+			return nil
+		}
+	}
+
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+
+	x := d.head.next[0]
+	if klo != nil {
+		x = d.ceiling(klo)
+	}
+
+	var items []Item
+	for ; x != nil && (khi == nil || d.cmp(x.key, khi) < 0); x = x.next[0] {
+		items = append(items, Item{Key: x.key.Name, Value: x.value})
+	}
+	return items
+}
+
+// Rank returns the position of key in the sorted order of d.
+// Returns the index starting at zero (0), or -1 if the key is not found.
+func (d *SortedDict) Rank(key interface{}) int {
+	k := MakeKey(key)
+	if k == nil || d.IsEmpty() {
+		return -1
+	}
+
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+
+	n, ok := d.nodes[k.ID]
+	if !ok {
+		return -1
+	}
+
+	var rank int
+	x := d.head
+	for i := d.level - 1; i >= 0; i-- {
+		for x.next[i] != nil && d.compare(x.next[i].key, n.key) <= 0 {
+			rank += x.span[i]
+			x = x.next[i]
+		}
+		if x == n {
+			return rank - 1
+		}
+	}
+	return -1
+}
+
+// Select returns the item at position i in the sorted order of d. A negative i counts
+// from the end, so Select(-1) is the last item.
+// Returns the item, or nil if i is out of range.
+func (d *SortedDict) Select(i int) *Item {
+	if d.IsEmpty() {
+		return nil
+	}
+
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+
+	size := int(atomic.LoadInt64(&d.size))
+	if i < 0 {
+		i += size
+	}
+	if i < 0 || i >= size {
+		return nil
+	}
+
+	var traversed int
+	x := d.head
+	for l := d.level - 1; l >= 0; l-- {
+		for x.next[l] != nil && traversed+x.span[l] <= i+1 {
+			traversed += x.span[l]
+			x = x.next[l]
+		}
+		if traversed == i+1 {
+			return x.item()
+		}
+	}
+	return nil
+}
+
+func (x *sortedNode) item() *Item {
+	return &Item{Key: x.key.Name, Value: x.value}
+}
+
+// String implements the fmt.Stringer interface to print d similar to a Python dict.
+// Returns a formatted string with the keys and values of the dict.
+func (d *SortedDict) String() string {
+	items := make([]string, 0, d.Len())
+	for _, item := range d.Range(nil, nil) {
+		items = append(items, fmt.Sprintf("%v: %#v", item.Key, item.Value))
+	}
+	return "{" + strings.Join(items, ", ") + "}"
+}
+
+// MarshalJSON implements the json.MarshalJSON interface.
+// The JSON representation of a sorted dict is a JSON object with the keys in sorted order.
+func (d *SortedDict) MarshalJSON() ([]byte, error) {
+	if d.IsEmpty() {
+		return []byte("null"), nil
+	}
+	return marshalItems(d.Items())
+}
+
+// UnmarshalJSON implements the json.UnmarshalJSON interface.
+// The JSON representation of a sorted dict is just a JSON object.
+func (d *SortedDict) UnmarshalJSON(p []byte) error {
+	var m map[string]interface{}
+	if err := json.Unmarshal(p, &m); err != nil {
+		return err
+	}
+
+	for k, v := range m {
+		m[k] = fromJSON(v, nil)
+	}
+	d.Update(m)
+
+	return nil
+}
@@ -0,0 +1,159 @@
+// Copyright (c) 2025 srfrog - https://srfrog.dev
+// Use of this source code is governed by the license in the LICENSE file.
+
+package dict
+
+import (
+	"encoding/json"
+	"math/rand"
+	"sort"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSortedDict(t *testing.T) {
+	d := NewSorted(nil, map[int]string{10: "ten", 2: "two", 33: "thirty-three"})
+	require.Equal(t, 3, d.Len())
+	require.Equal(t, []string{"2", "10", "33"}, d.Keys())
+	require.Equal(t, []interface{}{"two", "ten", "thirty-three"}, d.Values())
+
+	ver := d.Version()
+	d.Set(5, "five").Set(1, "one").Set(10, "TEN")
+	require.Equal(t, ver+3, d.Version())
+	d.Set(5, "five")
+	require.Equal(t, ver+3, d.Version())
+
+	require.Equal(t, []string{"1", "2", "5", "10", "33"}, d.Keys())
+	require.Equal(t, "TEN", d.Get(10))
+	require.Equal(t, "TEN", d.Get("10"))
+	require.Nil(t, d.Get(11))
+	require.Equal(t, "alt", d.Get(11, "alt"))
+	require.True(t, d.Key(33))
+	require.False(t, d.Key(nil))
+
+	require.True(t, d.Del(2))
+	require.False(t, d.Del(2))
+	require.Equal(t, []string{"1", "5", "10", "33"}, d.Keys())
+	require.Equal(t, "{1: \"one\", 5: \"five\", 10: \"TEN\", 33: \"thirty-three\"}", d.String())
+
+	var keys []interface{}
+	for item := range d.Items() {
+		keys = append(keys, item.Key)
+	}
+	require.Equal(t, []interface{}{"1", "5", "10", "33"}, keys)
+
+	require.True(t, d.Clear())
+	require.False(t, d.Clear())
+	require.True(t, d.IsEmpty())
+	require.Nil(t, d.Keys())
+	require.Nil(t, d.First())
+	require.Nil(t, d.Last())
+
+	var nd *SortedDict
+	require.Equal(t, 1, nd.Set("a", 1).Len())
+	require.Equal(t, 1, (&SortedDict{}).Set("a", 1).Len())
+}
+
+func TestSortedDictOrdered(t *testing.T) {
+	d := NewSorted(nil)
+	for _, n := range []int{50, 10, 40, 20, 30} {
+		d.Set(n, n*10)
+	}
+
+	tests := []struct {
+		name string
+		out  interface{}
+		item *Item
+	}{
+		{"first", d.First(), &Item{Key: "10", Value: 100}},
+		{"last", d.Last(), &Item{Key: "50", Value: 500}},
+		{"floor exact", d.Floor(30), &Item{Key: "30", Value: 300}},
+		{"floor", d.Floor(35), &Item{Key: "30", Value: 300}},
+		{"floor low", d.Floor(5), nil},
+		{"floor high", d.Floor(100), &Item{Key: "50", Value: 500}},
+		{"ceiling exact", d.Ceiling(30), &Item{Key: "30", Value: 300}},
+		{"ceiling", d.Ceiling(35), &Item{Key: "40", Value: 400}},
+		{"ceiling low", d.Ceiling(5), &Item{Key: "10", Value: 100}},
+		{"ceiling high", d.Ceiling(51), nil},
+		{"select", d.Select(1), &Item{Key: "20", Value: 200}},
+		{"select negative", d.Select(-2), &Item{Key: "40", Value: 400}},
+		{"select out of range", d.Select(5), nil},
+	}
+	for _, tc := range tests {
+		if tc.item == nil {
+			require.Nil(t, tc.out, tc.name)
+			continue
+		}
+		require.Equal(t, tc.item, tc.out, tc.name)
+	}
+
+	require.Equal(t, 0, d.Rank(10))
+	require.Equal(t, 4, d.Rank(50))
+	require.Equal(t, -1, d.Rank(35))
+
+	require.Equal(t, []Item{{Key: "20", Value: 200}, {Key: "30", Value: 300}}, d.Range(15, 40))
+	require.Equal(t, []Item{{Key: "10", Value: 100}, {Key: "20", Value: 200}}, d.Range(nil, 30))
+	require.Equal(t, []Item{{Key: "40", Value: 400}, {Key: "50", Value: 500}}, d.Range(40, nil))
+	require.Nil(t, d.Range(60, nil))
+	require.Len(t, d.Range(nil, nil), 5)
+}
+
+func TestSortedDictCompare(t *testing.T) {
+	// Case insensitive keys in reverse order.
+	cmp := func(a, b *Key) int {
+		return -strings.Compare(strings.ToLower(a.Name), strings.ToLower(b.Name))
+	}
+	d := NewSorted(cmp, New().Set("b", 1).Set("A", 2).Set("c", 3).Set("a", 4))
+
+	require.Equal(t, []string{"c", "b", "A", "a"}, d.Keys())
+	require.Equal(t, &Item{Key: "b", Value: 1}, d.Floor("B"))
+	require.Equal(t, 3, d.Rank("a"))
+}
+
+func TestSortedDictRandom(t *testing.T) {
+	rnd := rand.New(rand.NewSource(1))
+	d := NewSorted(nil)
+	ref := make(map[int]bool)
+
+	for i := 0; i < 2000; i++ {
+		n := rnd.Intn(500)
+		if rnd.Intn(3) == 0 {
+			require.Equal(t, ref[n], d.Del(n))
+			delete(ref, n)
+			continue
+		}
+		d.Set(n, i)
+		ref[n] = true
+	}
+
+	keys := make([]int, 0, len(ref))
+	for n := range ref {
+		keys = append(keys, n)
+	}
+	sort.Ints(keys)
+
+	require.Equal(t, len(keys), d.Len())
+	for i, n := range keys {
+		require.Equal(t, i, d.Rank(n))
+		require.Equal(t, strconv.Itoa(n), d.Select(i).Key)
+	}
+}
+
+func TestSortedDictJSON(t *testing.T) {
+	d := NewSorted(nil)
+	require.NoError(t, json.Unmarshal([]byte(`{"b": 2, "10": [1, 2], "a": {"x": 1}, "9": null}`), d))
+
+	b, err := json.Marshal(d)
+	require.NoError(t, err)
+	require.Equal(t, `{"9":null,"10":[1,2],"a":{"x":1},"b":2}`, string(b))
+
+	b, err = json.Marshal(NewSorted(nil))
+	require.NoError(t, err)
+	require.Equal(t, "null", string(b))
+
+	// Dicts update each other.
+	require.Equal(t, []string{"9", "10", "a", "b"}, New(d).Keys())
+}
@@ -0,0 +1,210 @@
+// Copyright (c) 2025 srfrog - https://srfrog.dev
+// Use of this source code is governed by the license in the LICENSE file.
+
+package dict
+
+import (
+	"errors"
+	"sync/atomic"
+)
+
+// Errors returned by Tx.
+var (
+	ErrTxConflict = errors.New("dict: transaction conflict")
+	ErrTxDone     = errors.New("dict: transaction has already been committed or rolled back")
+	ErrTxNilDict  = errors.New("dict: transaction on a nil dict")
+)
+
+// Tx is a transaction on a dict. Changes made with a Tx are kept in a private overlay,
+// which is seen by the Tx but not by the dict, until Commit applies all of them at once.
+//
+// Conflicts are detected optimistically: Commit fails with ErrTxConflict if the dict was
+// changed after Begin. A Tx must be used by a single goroutine.
+// Use Dict.Begin to create a Tx.
+type Tx struct {
+	d       *Dict
+	version int
+	delta   int
+	ops     []txOp
+	overlay map[string]txOp
+	done    bool
+	err     error
+}
+
+// txOp is a change in a Tx. If del is true, the item of the key named name is removed,
+// otherwise its value is set. The key is hashed when the change is applied, with the
+// Hasher of the dict at that time.
+type txOp struct {
+	name  string
+	value interface{}
+	del   bool
+}
+
+// Begin starts a new transaction on d. If d is nil, the changes can't be applied, so Commit
+// returns ErrTxNilDict.
+func (d *Dict) Begin() *Tx {
+	// Sanity: don't panic on nil dict, work on an empty one that is never committed.
+	if d == nil {
+		tx := New().Begin()
+		tx.err = ErrTxNilDict
+		return tx
+	}
+	return &Tx{
+		d:       d,
+		version: d.Version(),
+		overlay: make(map[string]txOp),
+	}
+}
+
+// lookup returns the value of the key named name as seen by tx, and true if found.
+func (tx *Tx) lookup(name string) (interface{}, bool) {
+	if op, ok := tx.overlay[name]; ok {
+		return op.value, !op.del
+	}
+
+	tx.d.mu.RLock()
+	defer tx.d.mu.RUnlock()
+
+	id, _ := tx.d.keyID(name)
+	v, ok := tx.d.values[id]
+	return v, ok
+}
+
+// keyName returns the name of the Key made from key, and true if key is valid.
+func keyName(key interface{}) (string, bool) {
+	k, ok := makeKey(key, nil)
+	return k.Name, ok
+}
+
+// record adds a change to tx, keeping track of the size of the dict.
+func (tx *Tx) record(op txOp) {
+	_, found := tx.lookup(op.name)
+	switch {
+	case op.del && found:
+		tx.delta--
+	case !op.del && !found:
+		tx.delta++
+	}
+	tx.ops = append(tx.ops, op)
+	tx.overlay[op.name] = op
+}
+
+// Len returns the size of the dict as seen by tx.
+func (tx *Tx) Len() int {
+	return tx.d.Len() + tx.delta
+}
+
+// Set inserts or replaces an item in tx. Nothing is done if tx is done.
+func (tx *Tx) Set(key, value interface{}) *Tx {
+	if name, ok := keyName(key); ok && !tx.done {
+		tx.record(txOp{name: name, value: value})
+	}
+	return tx
+}
+
+// Get retrieves an item by key, as seen by tx. If alt value is passed, it will be used as
+// default value if no item is found. Unlike Dict.Get, default factories are not used.
+// Returns a value matching key, otherwise nil or alt if given.
+func (tx *Tx) Get(key interface{}, alt ...interface{}) interface{} {
+	if name, ok := keyName(key); ok {
+		if v, ok := tx.lookup(name); ok {
+			return v
+		}
+	}
+	if alt != nil {
+		return alt[0]
+	}
+	return nil
+}
+
+// Key returns true if key is in the dict as seen by tx, false otherwise.
+func (tx *Tx) Key(key interface{}) bool {
+	name, ok := keyName(key)
+	if !ok {
+		return false
+	}
+	_, ok = tx.lookup(name)
+	return ok
+}
+
+// Del removes an item from tx by key.
+// Returns true if an item is found and removed, false otherwise.
+func (tx *Tx) Del(key interface{}) bool {
+	name, ok := keyName(key)
+	if !ok || tx.done || !tx.Key(key) {
+		return false
+	}
+	tx.record(txOp{name: name, del: true})
+	return true
+}
+
+// Update adds to tx the key-value items from iterables, scalars and other dicts, as in
+// Dict.Update().
+func (tx *Tx) Update(vargs ...interface{}) *Tx {
+	for i := range vargs {
+		// other dict
+		if other, ok := vargs[i].(itemsSource); ok {
+			for item := range other.Items() {
+				tx.Set(item.Key, item.Value)
+			}
+			continue
+		}
+		// iterables and scalars
+		for item := range toIterable(vargs[i]) {
+			if item.Key == nil {
+				item.Key = tx.Len()
+			}
+			tx.Set(item.Key, item.Value)
+		}
+	}
+	return tx
+}
+
+// Commit applies all the changes of tx to the dict under one lock, and increases its
+// version once if anything changed. After Commit, tx is done.
+// Returns ErrTxConflict if the dict was changed after Begin, in which case nothing is
+// applied, ErrTxNilDict if tx was started on a nil dict, or ErrTxDone if tx is already done.
+func (tx *Tx) Commit() error {
+	if tx.done {
+		return ErrTxDone
+	}
+	tx.done = true
+	if tx.err != nil {
+		return tx.err
+	}
+
+	d := tx.d
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	ver := d.Version()
+	if ver != tx.version {
+		return ErrTxConflict
+	}
+
+	for _, op := range tx.ops {
+		k, _ := d.makeKey(op.name)
+		if op.del {
+			d.deleteKey(k.ID)
+			continue
+		}
+		d.setKey(k, op.value)
+	}
+
+	if d.Version() != ver {
+		atomic.StoreInt64(&d.version, int64(ver+1))
+	}
+
+	return nil
+}
+
+// Rollback discards all the changes of tx. After Rollback, tx is done.
+// Returns ErrTxDone if tx is already done.
+func (tx *Tx) Rollback() error {
+	if tx.done {
+		return ErrTxDone
+	}
+	tx.done = true
+	tx.ops, tx.overlay = nil, nil
+	return nil
+}
@@ -0,0 +1,84 @@
+// Copyright (c) 2025 srfrog - https://srfrog.dev
+// Use of this source code is governed by the license in the LICENSE file.
+
+package dict
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestTxCommit(t *testing.T) {
+	d := New().Set("a", 1).Set("b", 2)
+	ver := d.Version()
+
+	tx := d.Begin()
+	tx.Set("a", 10).Set("c", 3).Set(nil, 4)
+	require.True(t, tx.Del("b"))
+	require.False(t, tx.Del("b"))
+	require.False(t, tx.Del("missing"))
+	tx.Update([]string{"x"})
+
+	// Changes are only seen by the transaction.
+	require.Equal(t, 10, tx.Get("a"))
+	require.Nil(t, tx.Get("b"))
+	require.Equal(t, "alt", tx.Get("b", "alt"))
+	require.True(t, tx.Key("c"))
+	require.Equal(t, "x", tx.Get("2"))
+	require.Equal(t, 3, tx.Len())
+	require.Equal(t, 1, d.Get("a"))
+	require.False(t, d.Key("c"))
+
+	require.NoError(t, tx.Commit())
+	require.Equal(t, ver+1, d.Version())
+	require.Equal(t, []string{"a", "c", "2"}, d.Keys())
+	require.Equal(t, 10, d.Get("a"))
+
+	require.Equal(t, ErrTxDone, tx.Commit())
+	require.Equal(t, ErrTxDone, tx.Rollback())
+	tx.Set("z", 1)
+	require.False(t, d.Key("z"))
+}
+
+func TestTxRollback(t *testing.T) {
+	d := New().Set("a", 1)
+	ver := d.Version()
+
+	tx := d.Begin()
+	tx.Set("a", 2)
+	tx.Del("a")
+	require.False(t, tx.Key("a"))
+	require.NoError(t, tx.Rollback())
+	require.Equal(t, ErrTxDone, tx.Commit())
+	require.Equal(t, 1, d.Get("a"))
+	require.Equal(t, ver, d.Version())
+
+	// A transaction without changes doesn't change the version.
+	require.NoError(t, d.Begin().Set("a", 1).Commit())
+	require.Equal(t, ver, d.Version())
+}
+
+func TestTxConflict(t *testing.T) {
+	d := New().Set("a", 1)
+
+	tx1 := d.Begin()
+	tx2 := d.Begin()
+	tx1.Set("a", 2)
+	tx2.Set("b", 3)
+
+	require.NoError(t, tx1.Commit())
+	require.Equal(t, ErrTxConflict, tx2.Commit())
+	require.False(t, d.Key("b"))
+
+	tx := d.Begin().Set("c", 1)
+	d.Set("a", 5)
+	require.Equal(t, ErrTxConflict, tx.Commit())
+	require.False(t, d.Key("c"))
+
+	var nd *Dict
+	tx = nd.Begin().Set("a", 1)
+	require.Equal(t, 1, tx.Get("a"))
+	require.Equal(t, ErrTxNilDict, tx.Commit())
+	require.Equal(t, ErrTxDone, tx.Commit())
+}
@@ -0,0 +1,286 @@
+// Copyright (c) 2025 srfrog - https://srfrog.dev
+// Use of this source code is governed by the license in the LICENSE file.
+
+package dict
+
+import (
+	"errors"
+	"sync/atomic"
+)
+
+// ErrChangedDuringIteration is the error of an Iterator when its dict was changed after the
+// iteration started.
+var ErrChangedDuringIteration = errors.New("dict: dict changed during iteration")
+
+// KeysView is a set-like view of the keys in a dict, like Python dict.keys().
+// Views are live, they read through to the dict, so they always reflect its current state.
+// The set operations return new dicts with the items of the operands, preserving the order
+// of the left operand followed by the order of the right operand.
+type KeysView struct {
+	d *Dict
+}
+
+// ValuesView is a view of the values in a dict, like Python dict.values().
+// Views are live, they read through to the dict, so they always reflect its current state.
+type ValuesView struct {
+	d *Dict
+}
+
+// ItemsView is a set-like view of the key-value items in a dict, like Python dict.items().
+// Two items are the same if they have the same key and equal values, as compared by Equal.
+// Views are live, they read through to the dict, so they always reflect its current state.
+// The set operations return new dicts with the items of the operands, preserving the order
+// of the left operand followed by the order of the right operand.
+type ItemsView struct {
+	d *Dict
+}
+
+// KeysView returns a live set-like view of the keys in d.
+func (d *Dict) KeysView() KeysView {
+	return KeysView{d: d}
+}
+
+// ValuesView returns a live view of the values in d.
+func (d *Dict) ValuesView() ValuesView {
+	return ValuesView{d: d}
+}
+
+// ItemsView returns a live set-like view of the items in d.
+func (d *Dict) ItemsView() ItemsView {
+	return ItemsView{d: d}
+}
+
+// Len returns the number of keys in the dict.
+func (v KeysView) Len() int { return v.d.Len() }
+
+// Len returns the number of values in the dict.
+func (v ValuesView) Len() int { return v.d.Len() }
+
+// Len returns the number of items in the dict.
+func (v ItemsView) Len() int { return v.d.Len() }
+
+// Contains returns true if key is in the dict, false otherwise.
+func (v KeysView) Contains(key interface{}) bool {
+	return v.d.Key(key)
+}
+
+// Contains returns true if a value in the dict is equal to value, false otherwise.
+func (v ValuesView) Contains(value interface{}) bool {
+	for it := v.Iter(); it.Next(); {
+		if valuesEqual(it.Value(), value) {
+			return true
+		}
+	}
+	return false
+}
+
+// Contains returns true if the dict has an item with the same key and an equal value,
+// false otherwise.
+func (v ItemsView) Contains(item Item) bool {
+	return hasItem(v.d, item)
+}
+
+// Iter returns an iterator over the keys of the dict, in order.
+func (v KeysView) Iter() *Iterator { return newIterator(v.d) }
+
+// Iter returns an iterator over the values of the dict, in order.
+func (v ValuesView) Iter() *Iterator { return newIterator(v.d) }
+
+// Iter returns an iterator over the items of the dict, in order.
+func (v ItemsView) Iter() *Iterator { return newIterator(v.d) }
+
+// Iterator iterates over the current items of a dict, reading each item when Next is
+// called. If the dict is changed during the iteration, the iteration stops and Err returns
+// ErrChangedDuringIteration, like the Python error "dictionary changed size during
+// iteration". Changes are detected with the dict version.
+//
+//	for it := d.ItemsView().Iter(); it.Next(); {
+//		fmt.Println(it.Key(), it.Value())
+//	}
+type Iterator struct {
+	d       *Dict
+	version int
+	pos     int
+	item    Item
+	err     error
+}
+
+func newIterator(d *Dict) *Iterator {
+	if d == nil {
+		return &Iterator{}
+	}
+	return &Iterator{d: d, version: d.Version()}
+}
+
+// Next advances the iterator to the next item.
+// Returns true if there is an item, false if the iteration is done or an error occurred.
+func (it *Iterator) Next() bool {
+	if it.err != nil || it.d == nil {
+		return false
+	}
+
+	it.d.mu.RLock()
+	defer it.d.mu.RUnlock()
+
+	if int(atomic.LoadInt64(&it.d.version)) != it.version {
+		it.err = ErrChangedDuringIteration
+		return false
+	}
+	if it.pos >= len(it.d.keys) {
+		return false
+	}
+
+	key := it.d.keys[it.pos]
+	it.item = Item{Key: key.Name, Value: it.d.values[key.ID]}
+	it.pos++
+
+	return true
+}
+
+// Key returns the key name of the current item.
+func (it *Iterator) Key() string {
+	name, _ := it.item.Key.(string)
+	return name
+}
+
+// Value returns the value of the current item.
+func (it *Iterator) Value() interface{} {
+	return it.item.Value
+}
+
+// Item returns the current item.
+func (it *Iterator) Item() Item {
+	return it.item
+}
+
+// Err returns the error that stopped the iteration, or nil if there was none.
+func (it *Iterator) Err() error {
+	return it.err
+}
+
+// Union returns a new dict with the items of the view dict, followed by the items of other
+// whose keys are not in the view.
+func (v KeysView) Union(other *Dict) *Dict {
+	res := New()
+	for item := range v.d.Items() {
+		res.Set(item.Key, item.Value)
+	}
+	for item := range other.Items() {
+		if !res.Key(item.Key) {
+			res.Set(item.Key, item.Value)
+		}
+	}
+	return res
+}
+
+// Intersection returns a new dict with the items of the view dict whose keys are also in
+// other.
+func (v KeysView) Intersection(other *Dict) *Dict {
+	return filterItems(v.d, func(item Item) bool { return other.Key(item.Key) })
+}
+
+// Difference returns a new dict with the items of the view dict whose keys are not in
+// other.
+func (v KeysView) Difference(other *Dict) *Dict {
+	return filterItems(v.d, func(item Item) bool { return !other.Key(item.Key) })
+}
+
+// SymmetricDifference returns a new dict with the items whose keys are either in the view
+// or in other, but not in both.
+func (v KeysView) SymmetricDifference(other *Dict) *Dict {
+	res := v.Difference(other)
+	for item := range other.Items() {
+		if !v.d.Key(item.Key) {
+			res.Set(item.Key, item.Value)
+		}
+	}
+	return res
+}
+
+// IsSubset returns true if all the keys in the view are also in other, false otherwise.
+func (v KeysView) IsSubset(other *Dict) bool {
+	for _, key := range v.d.Keys() {
+		if !other.Key(key) {
+			return false
+		}
+	}
+	return true
+}
+
+// Union returns a new dict with the items in the view dict or in other. If both have
+// different values for the same key, the value in other is used at the position of the
+// key in the view, just like Or.
+func (v ItemsView) Union(other *Dict) *Dict {
+	return v.d.Or(other)
+}
+
+// Intersection returns a new dict with the items of the view dict that are also in other.
+func (v ItemsView) Intersection(other *Dict) *Dict {
+	return filterItems(v.d, func(item Item) bool { return hasItem(other, item) })
+}
+
+// Difference returns a new dict with the items of the view dict that are not in other.
+func (v ItemsView) Difference(other *Dict) *Dict {
+	return filterItems(v.d, func(item Item) bool { return !hasItem(other, item) })
+}
+
+// SymmetricDifference returns a new dict with the items that are either in the view or in
+// other, but not in both. If both have different values for the same key, the value in
+// other is used at the position of the key in the view.
+func (v ItemsView) SymmetricDifference(other *Dict) *Dict {
+	res := v.Difference(other)
+	for item := range other.Items() {
+		if !hasItem(v.d, item) {
+			res.Set(item.Key, item.Value)
+		}
+	}
+	return res
+}
+
+// IsSubset returns true if all the items in the view are also in other, false otherwise.
+func (v ItemsView) IsSubset(other *Dict) bool {
+	for item := range v.d.Items() {
+		if !hasItem(other, item) {
+			return false
+		}
+	}
+	return true
+}
+
+// Or returns a new dict with the items of d updated with the items of other, like the
+// Python 3.9 operator d | other.
+func (d *Dict) Or(other *Dict) *Dict {
+	res := New()
+	for item := range d.Items() {
+		res.Set(item.Key, item.Value)
+	}
+	return res.OrUpdate(other)
+}
+
+// OrUpdate updates d in place with the items of other, like the Python 3.9 operator
+// d |= other.
+// Returns d.
+func (d *Dict) OrUpdate(other *Dict) *Dict {
+	if d == nil {
+		d = New()
+	}
+	for item := range other.Items() {
+		d.Set(item.Key, item.Value)
+	}
+	return d
+}
+
+func filterItems(d *Dict, fn func(Item) bool) *Dict {
+	res := New()
+	for item := range d.Items() {
+		if fn(item) {
+			res.Set(item.Key, item.Value)
+		}
+	}
+	return res
+}
+
+func hasItem(d *Dict, item Item) bool {
+	value, ok := d.lookup(item.Key)
+	return ok && valuesEqual(value, item.Value)
+}
@@ -0,0 +1,153 @@
+// Copyright (c) 2025 srfrog - https://srfrog.dev
+// Use of this source code is governed by the license in the LICENSE file.
+
+package dict
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func newViewDicts() (*Dict, *Dict) {
+	a := New().Set("a", 1).Set("b", 2).Set("c", 3)
+	b := New().Set("d", 4).Set("c", 30).Set("b", 2)
+	return a, b
+}
+
+func TestKeysViewSetOps(t *testing.T) {
+	a, b := newViewDicts()
+	tests := []struct {
+		name string
+		out  *Dict
+		keys []string
+		vals []interface{}
+	}{
+		{"union", a.KeysView().Union(b), []string{"a", "b", "c", "d"}, []interface{}{1, 2, 3, 4}},
+		{"intersection", a.KeysView().Intersection(b), []string{"b", "c"}, []interface{}{2, 3}},
+		{"difference", a.KeysView().Difference(b), []string{"a"}, []interface{}{1}},
+		{"symmetric difference", a.KeysView().SymmetricDifference(b), []string{"a", "d"}, []interface{}{1, 4}},
+		{"empty", a.KeysView().Intersection(New()), nil, nil},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			require.Equal(t, tc.keys, tc.out.Keys())
+			require.Equal(t, tc.vals, tc.out.Values())
+		})
+	}
+
+	require.True(t, a.KeysView().IsSubset(a))
+	require.False(t, a.KeysView().IsSubset(b))
+	require.True(t, New().Set("b", 0).KeysView().IsSubset(b))
+	require.True(t, New().KeysView().IsSubset(nil))
+}
+
+func TestItemsViewSetOps(t *testing.T) {
+	a, b := newViewDicts()
+	tests := []struct {
+		name string
+		out  *Dict
+		keys []string
+		vals []interface{}
+	}{
+		{"union", a.ItemsView().Union(b), []string{"a", "b", "c", "d"}, []interface{}{1, 2, 30, 4}},
+		{"intersection", a.ItemsView().Intersection(b), []string{"b"}, []interface{}{2}},
+		{"difference", a.ItemsView().Difference(b), []string{"a", "c"}, []interface{}{1, 3}},
+		{"symmetric difference", a.ItemsView().SymmetricDifference(b), []string{"a", "c", "d"}, []interface{}{1, 30, 4}},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			require.Equal(t, tc.keys, tc.out.Keys())
+			require.Equal(t, tc.vals, tc.out.Values())
+		})
+	}
+
+	require.True(t, a.ItemsView().IsSubset(a))
+	require.False(t, a.ItemsView().IsSubset(b))
+	require.True(t, New().Set("b", 2.0).ItemsView().IsSubset(b))
+	require.False(t, New().Set("c", 3).ItemsView().IsSubset(b))
+}
+
+func TestOr(t *testing.T) {
+	a, b := newViewDicts()
+
+	out := a.Or(b)
+	require.Equal(t, []string{"a", "b", "c", "d"}, out.Keys())
+	require.Equal(t, []interface{}{1, 2, 30, 4}, out.Values())
+
+	// Operands are not changed.
+	require.Equal(t, []interface{}{1, 2, 3}, a.Values())
+	require.Equal(t, []interface{}{4, 30, 2}, b.Values())
+
+	out = b.Or(a)
+	require.Equal(t, []string{"d", "c", "b", "a"}, out.Keys())
+	require.Equal(t, []interface{}{4, 3, 2, 1}, out.Values())
+
+	require.Equal(t, a, a.OrUpdate(b))
+	require.Equal(t, []string{"a", "b", "c", "d"}, a.Keys())
+	require.Equal(t, []interface{}{1, 2, 30, 4}, a.Values())
+
+	var d *Dict
+	require.Equal(t, []string{"d", "c", "b"}, d.OrUpdate(b).Keys())
+}
+
+func TestViewsAreLive(t *testing.T) {
+	d := New().Set("a", 1).Set("b", 2)
+	keys, values, items := d.KeysView(), d.ValuesView(), d.ItemsView()
+
+	require.Equal(t, 2, keys.Len())
+	require.True(t, keys.Contains("a"))
+	require.False(t, keys.Contains("c"))
+	require.True(t, values.Contains(2.0))
+	require.False(t, values.Contains(3))
+	require.True(t, items.Contains(Item{Key: "b", Value: 2}))
+	require.False(t, items.Contains(Item{Key: "b", Value: 3}))
+
+	d.Set("c", 3).Del("a")
+	require.Equal(t, 2, values.Len())
+	require.True(t, keys.Contains("c"))
+	require.False(t, keys.Contains("a"))
+	require.True(t, values.Contains(3))
+	require.True(t, items.Contains(Item{Key: "c", Value: 3}))
+
+	var got []Item
+	it := items.Iter()
+	for it.Next() {
+		require.Equal(t, it.Item().Key, it.Key())
+		got = append(got, it.Item())
+	}
+	require.NoError(t, it.Err())
+	require.Equal(t, []Item{{Key: "b", Value: 2}, {Key: "c", Value: 3}}, got)
+
+	d.Clear()
+	require.Zero(t, keys.Len())
+	require.False(t, keys.Iter().Next())
+}
+
+func TestIteratorChangedDuringIteration(t *testing.T) {
+	d := New(1, 2, 3)
+
+	var keys []string
+	it := d.KeysView().Iter()
+	for it.Next() {
+		keys = append(keys, it.Key())
+		if it.Key() == "1" {
+			d.Set("x", "y")
+		}
+	}
+	require.Equal(t, []string{"0", "1"}, keys)
+	require.Equal(t, ErrChangedDuringIteration, it.Err())
+	require.False(t, it.Next())
+
+	// Setting the same value is not a change.
+	it = d.ValuesView().Iter()
+	require.True(t, it.Next())
+	d.Set(0, 1)
+	require.True(t, it.Next())
+	require.NoError(t, it.Err())
+
+	var nd *Dict
+	it = nd.ItemsView().Iter()
+	require.False(t, it.Next())
+	require.NoError(t, it.Err())
+}